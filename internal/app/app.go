@@ -2,9 +2,11 @@ package app
 
 import (
 	"byoman/internal/byobu"
+	"byoman/internal/config"
 	"byoman/internal/tui"
 	"fmt"
 	"os"
+	"strings"
 	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,10 +20,19 @@ func Run() error {
 		return err
 	}
 
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
 	client := byobu.NewClient()
-	model := tui.NewModel(client)
+	model, err := tui.NewModel(client, cfg)
+	if err != nil {
+		path, _ := config.Path()
+		return fmt.Errorf("%s: %w", path, err)
+	}
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	finalModel, err := p.Run()
 	if err != nil {
 		return fmt.Errorf("error running program: %w", err)
@@ -29,16 +40,20 @@ func Run() error {
 
 	// Check if user selected a session to attach
 	m := finalModel.(tui.Model)
+	if lines := m.ShareInstructions(); lines != nil {
+		fmt.Println(strings.Join(lines, "\n"))
+		return nil
+	}
 	if sessionName := m.SelectedSession(); sessionName != "" {
-		return attachToSession(client, sessionName)
+		return attachToSession(client, sessionName, m.AttachOptions())
 	}
 
 	return nil
 }
 
 // attachToSession replaces the current process with byobu attach.
-func attachToSession(client *byobu.DefaultClient, name string) error {
-	binary, args, err := client.AttachSessionArgs(name)
+func attachToSession(client *byobu.DefaultClient, name string, opts byobu.AttachOptions) error {
+	binary, args, err := client.AttachSessionArgs(name, opts)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"byoman/internal/byobu"
+	"byoman/internal/config"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// New implements `byoman new [flags] [name] [-- command]`.
+// It creates a session, named after the start directory if no name is
+// given, and prints its name or attaches to it.
+func New(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	dir := fs.String("c", "", "start directory (default: current)")
+	window := fs.String("n", "", "name of the first window")
+	profileName := fs.String("p", cfg.Profile, "option profile to apply, or none")
+	attach := fs.Bool("a", false, "attach to the session after creating it")
+	var env []string
+	fs.Func("e", "set an environment variable in the session, as KEY=VALUE (repeatable)", func(s string) error {
+		if err := byobu.ValidateEnv([]string{s}); err != nil {
+			return err
+		}
+		env = append(env, s)
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: byoman new [flags] [name] [-- command]")
+		fmt.Fprintln(fs.Output(), "\nThe command runs in the first window instead of a shell.")
+		fs.PrintDefaults()
+	}
+	// Split off the command first: flag.Parse would swallow a "--" that
+	// directly follows the flags
+	var command []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, command = args[:i], args[i+1:]
+			break
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var name string
+	switch rest := fs.Args(); len(rest) {
+	case 0:
+	case 1:
+		name = rest[0]
+	default:
+		fs.Usage()
+		return fmt.Errorf("new: unexpected arguments after name; put the command after --")
+	}
+
+	var profile *byobu.Profile
+	if *profileName != "none" {
+		p, err := byobu.FindProfile(byobu.Profiles(), *profileName)
+		if err != nil {
+			return err
+		}
+		profile = &p
+	}
+
+	if err := byobu.CheckVersion(); err != nil {
+		return err
+	}
+
+	client := byobu.NewClient()
+	if name == "" {
+		start := *dir
+		if start == "" {
+			start, _ = os.Getwd()
+		}
+		sessions, err := client.ListSessions()
+		if err != nil {
+			return err
+		}
+		names := make([]string, len(sessions))
+		for i, s := range sessions {
+			names[i] = s.Name
+		}
+		name = byobu.SuggestSessionName(start, names)
+	}
+
+	opts := byobu.NewSessionOptions{
+		StartDir:   *dir,
+		WindowName: *window,
+		Command:    byobu.ShellJoin(command),
+		Env:        env,
+	}
+	if err := client.NewSession(name, opts); err != nil {
+		return err
+	}
+	if profile != nil {
+		if err := client.ApplyProfile(name, *profile); err != nil {
+			fmt.Fprintf(os.Stderr, "byoman: profile '%s' not fully applied: %s\n", profile.Name, err)
+		}
+	}
+
+	if *attach {
+		return attachToSession(client, name, byobu.AttachOptions{})
+	}
+	fmt.Println(name)
+	return nil
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"byoman/internal/logs"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// PipeLog implements the internal `byoman pipe-log <path>` command that
+// byobu's pipe-pane runs. It copies pane output from stdin to path with
+// ANSI sequences stripped, rotating the file by size.
+func PipeLog(args []string) error {
+	fs := flag.NewFlagSet("pipe-log", flag.ContinueOnError)
+	maxSize := fs.Int64("max-size", logs.DefaultMaxSize, "rotate the log after this many bytes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: byoman pipe-log [-max-size N] <path>")
+	}
+
+	w, err := logs.NewRotatingWriter(fs.Arg(0), *maxSize)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	return logs.CopyStripped(w, os.Stdin)
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"byoman/internal/byobu"
+	"flag"
+	"fmt"
+)
+
+// Send implements `byoman send [-n] <target> -- <command>`.
+// It types the command into the target's active pane without attaching.
+func Send(args []string) error {
+	fs := flag.NewFlagSet("send", flag.ContinueOnError)
+	noEnter := fs.Bool("n", false, "don't press Enter after the command")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: byoman send [-n] <target> -- <command>")
+		fmt.Fprintln(fs.Output(), "\nTarget is a session name, session:window, session:window.pane or a pane ID (%N).")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rest := fs.Args()
+	if len(rest) < 1 {
+		fs.Usage()
+		return fmt.Errorf("send: missing target")
+	}
+	target, rest := rest[0], rest[1:]
+	if len(rest) > 0 && rest[0] == "--" {
+		rest = rest[1:]
+	}
+
+	if err := byobu.CheckVersion(); err != nil {
+		return err
+	}
+
+	client := byobu.NewClient()
+	return client.SendKeys(target, byobu.ShellJoin(rest), !*noEnter)
+}
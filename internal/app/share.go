@@ -0,0 +1,38 @@
+package app
+
+import (
+	"byoman/internal/byobu"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// Share implements `byoman share [-u user] <session>`.
+// It prints the commands that let another local user view the session
+// read-only; it changes nothing itself.
+func Share(args []string) error {
+	fs := flag.NewFlagSet("share", flag.ContinueOnError)
+	user := fs.String("u", "", "local user to share with (default: a USER placeholder)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: byoman share [-u user] <session>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("share: expected one session name")
+	}
+
+	if err := byobu.CheckVersion(); err != nil {
+		return err
+	}
+
+	info, err := byobu.NewClient().ShareInfo(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.Join(info.Instructions(*user), "\n"))
+	return nil
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"byoman/internal/byobu"
+	"byoman/internal/watch"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Watch implements `byoman watch`. It polls byobu and notifies when a pane
+// in a detached session rings a bell, goes silent after a burst of output,
+// or its foreground command exits, and when pane output matches a rule.
+func Watch(args []string) error {
+	defaults := watch.DefaultOptions()
+
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := fs.Duration("interval", 5*time.Second, "polling interval")
+	method := fs.String("notify", "auto", "notification method: osc9, osc777, notify-send, auto or none")
+	hook := fs.String("hook", "", "shell command to run per event (gets BYOMAN_EVENT, BYOMAN_SESSION, BYOMAN_WINDOW, BYOMAN_MESSAGE)")
+	silence := fs.Duration("silence", defaults.Silence, "quiet period after which a busy window counts as silent")
+	minRun := fs.Duration("min-run", defaults.MinRun, "minimum output burst before silence is reported")
+	var rules []watch.Rule
+	fs.Func("rule", "alert when pane output matches, as SESSION:REGEXP (repeatable, e.g. 'api:panic:|FAIL')", func(s string) error {
+		r, err := watch.ParseRule(s)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, r)
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: byoman watch [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := byobu.CheckVersion(); err != nil {
+		return err
+	}
+
+	notifiers, err := watch.NewNotifiers(*method, *hook, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := byobu.NewClient()
+	tracker := watch.NewTracker(watch.Options{Silence: *silence, MinRun: *minRun})
+	matcher := watch.NewMatcher(rules)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	fmt.Fprintf(os.Stderr, "byoman: watching detached sessions every %s (ctrl+c to stop)\n", *interval)
+	for {
+		sessions, panes, err := watch.Load(client)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		} else {
+			now := time.Now()
+			events := tracker.Update(sessions, panes, now)
+			events = append(events, matcher.Check(client, panes, now)...)
+			for _, e := range events {
+				fmt.Println(e)
+				for _, n := range notifiers {
+					if err := n.Notify(e); err != nil {
+						fmt.Fprintln(os.Stderr, err)
+					}
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
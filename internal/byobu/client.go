@@ -13,11 +13,37 @@ import (
 type Client interface {
 	ListSessions() ([]Session, error)
 	GetPaneCommands() (map[string][]string, error)
-	NewSession(name string) error
+	ListWindows() ([]Window, error)
+	ListPanes() ([]Pane, error)
+	NewSession(name string, opts NewSessionOptions) error
 	RenameSession(oldName, newName string) error
 	KillSession(name string) error
-	AttachSessionArgs(name string) (binary string, args []string, err error)
+	AttachSessionArgs(name string, opts AttachOptions) (binary string, args []string, err error)
 	ConfigureMinimalStatusBar(sessionName string) error
+	RestoreStatusBar(sessionName string) error
+	SetOption(sessionName, name, value string) error
+	UnsetOption(sessionName, name string) error
+	SessionOptions(sessionName string) ([]Option, error)
+	ApplyProfile(sessionName string, profile Profile) error
+	SendKeys(target, keys string, enter bool) error
+	CapturePane(target string, lines int) (Capture, error)
+	PipePane(target, command string) error
+	NewWindow(sessionName string, opts NewWindowOptions) error
+	RenameWindow(target, name string) error
+	KillWindow(target string) error
+	MoveWindow(target, dstSession string) error
+	SwapWindow(target, other string) error
+	LinkWindow(target, dstSession string) error
+	SplitPane(target string, opts SplitOptions) error
+	KillPane(target string) error
+	BreakPane(target, sessionName string) error
+	JoinPane(target, dstWindow string) error
+	ZoomPane(target string) error
+	SelectLayout(window, layout string) error
+	ListClients() ([]AttachedClient, error)
+	DetachClient(tty string) error
+	DetachOtherClients(sessionName, tty string) error
+	ShareInfo(sessionName string) (ShareInfo, error)
 }
 
 // DefaultClient implements Client using os/exec.
@@ -40,7 +66,7 @@ func CheckVersion() error {
 
 // ListSessions returns all byobu sessions.
 func (c *DefaultClient) ListSessions() ([]Session, error) {
-	format := "#{session_name}\t#{session_id}\t#{session_created}\t#{session_last_attached}\t#{session_attached}\t#{session_windows}"
+	format := "#{session_name}\t#{session_id}\t#{session_created}\t#{session_last_attached}\t#{session_attached}\t#{session_windows}\t#{session_activity}\t#{session_group}\t#{session_grouped}\t#{destroy-unattached}\t#{status-right}"
 	cmd := exec.Command("byobu", "list-sessions", "-F", format)
 
 	var stdout, stderr bytes.Buffer
@@ -66,7 +92,7 @@ func (c *DefaultClient) ListSessions() ([]Session, error) {
 
 	for _, line := range lines {
 		parts := strings.Split(line, "\t")
-		if len(parts) < 6 {
+		if len(parts) < 11 {
 			continue
 		}
 
@@ -74,14 +100,21 @@ func (c *DefaultClient) ListSessions() ([]Session, error) {
 		lastAttached, _ := strconv.ParseInt(parts[3], 10, 64)
 		attached, _ := strconv.Atoi(parts[4])
 		windowCount, _ := strconv.Atoi(parts[5])
+		activity, _ := strconv.ParseInt(parts[6], 10, 64)
+		statusRight := strings.Join(parts[10:], "\t")
 
 		sessions = append(sessions, Session{
 			Name:         parts[0],
 			ID:           parts[1],
 			Created:      time.Unix(created, 0),
 			LastAttached: time.Unix(lastAttached, 0),
+			Activity:     time.Unix(activity, 0),
 			Attached:     attached,
 			WindowCount:  windowCount,
+			Group:        parts[7],
+			Grouped:      parts[8] == "1",
+			Throwaway:    parts[9] == "on",
+			StatusRight:  statusRight,
 		})
 	}
 
@@ -136,12 +169,145 @@ func (c *DefaultClient) GetPaneCommands() (map[string][]string, error) {
 	return result, nil
 }
 
-// NewSession creates a new detached byobu session.
-func (c *DefaultClient) NewSession(name string) error {
+// ListWindows returns all windows across all sessions.
+func (c *DefaultClient) ListWindows() ([]Window, error) {
+	format := "#{session_name}\t#{window_index}\t#{window_name}\t#{window_id}\t#{window_panes}\t#{window_active}\t#{window_activity_flag}\t#{window_bell_flag}\t#{window_silence_flag}\t#{window_activity}\t#{window_linked}"
+	cmd := exec.Command("byobu", "list-windows", "-a", "-F", format)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		errMsg := stderr.String()
+		if strings.Contains(errMsg, "no server running") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("byobu list-windows: %s", strings.TrimSpace(errMsg))
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	if output == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(output, "\n")
+	windows := make([]Window, 0, len(lines))
+
+	for _, line := range lines {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 11 {
+			continue
+		}
+
+		index, _ := strconv.Atoi(parts[1])
+		paneCount, _ := strconv.Atoi(parts[4])
+		activity, _ := strconv.ParseInt(parts[9], 10, 64)
+
+		windows = append(windows, Window{
+			SessionName:  parts[0],
+			Index:        index,
+			Name:         parts[2],
+			ID:           parts[3],
+			PaneCount:    paneCount,
+			Active:       parts[5] == "1",
+			ActivityFlag: parts[6] == "1",
+			BellFlag:     parts[7] == "1",
+			SilenceFlag:  parts[8] == "1",
+			Activity:     time.Unix(activity, 0),
+			Linked:       parts[10] == "1",
+		})
+	}
+
+	return windows, nil
+}
+
+// ListPanes returns all panes across all sessions.
+func (c *DefaultClient) ListPanes() ([]Pane, error) {
+	format := "#{session_name}\t#{window_index}\t#{window_id}\t#{pane_index}\t#{pane_id}\t#{pane_pid}\t#{pane_current_command}\t#{pane_current_path}\t#{pane_active}\t#{pane_pipe}\t#{window_zoomed_flag}"
+	cmd := exec.Command("byobu", "list-panes", "-a", "-F", format)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		errMsg := stderr.String()
+		if strings.Contains(errMsg, "no server running") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("byobu list-panes: %s", strings.TrimSpace(errMsg))
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	if output == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(output, "\n")
+	panes := make([]Pane, 0, len(lines))
+
+	for _, line := range lines {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 11 {
+			continue
+		}
+
+		windowIndex, _ := strconv.Atoi(parts[1])
+		index, _ := strconv.Atoi(parts[3])
+		pid, _ := strconv.Atoi(parts[5])
+
+		panes = append(panes, Pane{
+			SessionName:    parts[0],
+			WindowIndex:    windowIndex,
+			WindowID:       parts[2],
+			Index:          index,
+			ID:             parts[4],
+			PID:            pid,
+			CurrentCommand: parts[6],
+			CurrentPath:    parts[7],
+			Active:         parts[8] == "1",
+			Piped:          parts[9] == "1",
+			Zoomed:         parts[10] == "1" && parts[8] == "1",
+		})
+	}
+
+	return panes, nil
+}
+
+// NewSession creates a new detached byobu session. An empty name lets tmux
+// pick one.
+func (c *DefaultClient) NewSession(name string, opts NewSessionOptions) error {
 	args := []string{"new-session", "-d"}
 	if name != "" {
+		if err := ValidateSessionName(name); err != nil {
+			return err
+		}
 		args = append(args, "-s", name)
 	}
+	if opts.StartDir != "" {
+		args = append(args, "-c", opts.StartDir)
+	}
+	if opts.GroupWith != "" {
+		if opts.WindowName != "" || opts.Command != "" {
+			return fmt.Errorf("a grouped session shares its windows, so it can't have its own window name or command")
+		}
+		args = append(args, "-t", opts.GroupWith)
+	}
+	if opts.WindowName != "" {
+		args = append(args, "-n", opts.WindowName)
+	}
+	if err := ValidateEnv(opts.Env); err != nil {
+		return err
+	}
+	for _, kv := range opts.Env {
+		args = append(args, "-e", kv)
+	}
+	if opts.Command != "" {
+		args = append(args, opts.Command)
+	}
 
 	cmd := exec.Command("byobu", args...)
 	var stderr bytes.Buffer
@@ -159,8 +325,8 @@ func (c *DefaultClient) NewSession(name string) error {
 
 // RenameSession renames an existing session.
 func (c *DefaultClient) RenameSession(oldName, newName string) error {
-	if newName == "" {
-		return fmt.Errorf("session name cannot be empty")
+	if err := ValidateSessionName(newName); err != nil {
+		return err
 	}
 
 	cmd := exec.Command("byobu", "rename-session", "-t", oldName, newName)
@@ -198,31 +364,23 @@ func (c *DefaultClient) KillSession(name string) error {
 
 // AttachSessionArgs returns the command to attach to a session.
 // Caller should use syscall.Exec with these args.
-func (c *DefaultClient) AttachSessionArgs(name string) (binary string, args []string, err error) {
+func (c *DefaultClient) AttachSessionArgs(name string, opts AttachOptions) (binary string, args []string, err error) {
 	binary, err = exec.LookPath("byobu")
 	if err != nil {
 		return "", nil, fmt.Errorf("byobu not found: %w", err)
 	}
-	args = []string{"byobu", "attach-session", "-t", name}
-	return binary, args, nil
-}
-
-// ConfigureMinimalStatusBar sets a minimal status bar (date/time only) for a session.
-// This applies per-session configuration without modifying global byobu settings.
-func (c *DefaultClient) ConfigureMinimalStatusBar(sessionName string) error {
-	// Minimal status: just date and time
-	statusRight := "%H:%M %d-%b"
-
-	cmd := exec.Command("byobu", "set-option", "-t", sessionName, "status-right", statusRight)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		errMsg := strings.TrimSpace(stderr.String())
-		if strings.Contains(errMsg, "can't find session") || strings.Contains(errMsg, "session not found") {
-			return fmt.Errorf("session '%s' not found", sessionName)
-		}
-		return fmt.Errorf("byobu set-option: %s", errMsg)
+	args = []string{"byobu", "attach-session"}
+	if opts.DetachOthers {
+		args = append(args, "-d")
 	}
-	return nil
+	if opts.ReadOnly {
+		args = append(args, "-r")
+	}
+	args = append(args, "-t", name)
+	if opts.Throwaway {
+		// Set only once attached: tmux destroys an unattached session as
+		// soon as the option is set
+		args = append(args, ";", "set-option", "-t", name, "destroy-unattached", "on")
+	}
+	return binary, args, nil
 }
@@ -0,0 +1,87 @@
+package byobu
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListClients returns the clients attached to any session.
+func (c *DefaultClient) ListClients() ([]AttachedClient, error) {
+	format := "#{client_session}\t#{client_tty}\t#{client_pid}\t#{client_width}\t#{client_height}\t#{client_termname}\t#{client_user}\t#{client_created}\t#{client_activity}\t#{client_readonly}"
+	cmd := exec.Command("byobu", "list-clients", "-F", format)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		errMsg := stderr.String()
+		if strings.Contains(errMsg, "no server running") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("byobu list-clients: %s", strings.TrimSpace(errMsg))
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	if output == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(output, "\n")
+	clients := make([]AttachedClient, 0, len(lines))
+
+	for _, line := range lines {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 10 {
+			continue
+		}
+
+		pid, _ := strconv.Atoi(parts[2])
+		width, _ := strconv.Atoi(parts[3])
+		height, _ := strconv.Atoi(parts[4])
+		created, _ := strconv.ParseInt(parts[7], 10, 64)
+		activity, _ := strconv.ParseInt(parts[8], 10, 64)
+
+		clients = append(clients, AttachedClient{
+			SessionName: parts[0],
+			TTY:         parts[1],
+			PID:         pid,
+			Width:       width,
+			Height:      height,
+			TermName:    parts[5],
+			User:        parts[6],
+			Created:     time.Unix(created, 0),
+			Activity:    time.Unix(activity, 0),
+			ReadOnly:    parts[9] == "1",
+		})
+	}
+
+	return clients, nil
+}
+
+// DetachClient detaches the client on a terminal, e.g. "/dev/pts/3".
+func (c *DefaultClient) DetachClient(tty string) error {
+	return c.runTarget("detach-client", tty, "-t", tty)
+}
+
+// DetachOtherClients detaches every client of a session except the one on
+// tty. tmux's own detach-client -a would detach clients of all sessions.
+func (c *DefaultClient) DetachOtherClients(sessionName, tty string) error {
+	clients, err := c.ListClients()
+	if err != nil {
+		return err
+	}
+	for _, cl := range clients {
+		if cl.SessionName == sessionName && cl.TTY != tty {
+			if err := c.DetachClient(cl.TTY); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package byobu
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxSessionNameLength caps session names, in characters, so they fit the
+// list and the status bar.
+const MaxSessionNameLength = 64
+
+// ValidateSessionName checks that tmux will use name as given. tmux
+// silently replaces "." and ":" (its target separators) with "_", so a
+// session created as "api.v2" couldn't be found under that name afterwards.
+func ValidateSessionName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("session name cannot be empty")
+	case strings.TrimSpace(name) != name:
+		return fmt.Errorf("session name cannot start or end with spaces")
+	case utf8.RuneCountInString(name) > MaxSessionNameLength:
+		return fmt.Errorf("session name is longer than %d characters", MaxSessionNameLength)
+	case strings.ContainsAny(name, ".:"):
+		return fmt.Errorf("session name cannot contain '.' or ':'")
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return fmt.Errorf("session name cannot contain control characters")
+	}
+	return nil
+}
+
+// ValidateEnv checks that every entry of vars is a KEY=VALUE pair.
+func ValidateEnv(vars []string) error {
+	for _, kv := range vars {
+		if key, _, ok := strings.Cut(kv, "="); !ok || key == "" {
+			return fmt.Errorf("invalid environment variable '%s' (want KEY=VALUE)", kv)
+		}
+	}
+	return nil
+}
+
+// SanitizeSessionName turns s into a valid session name by replacing
+// invalid characters with "-", or returns "" if nothing is left.
+func SanitizeSessionName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '.' || r == ':' || unicode.IsSpace(r) || unicode.IsControl(r) {
+			return '-'
+		}
+		return r
+	}, s)
+	return truncateName(strings.Trim(s, "-"), MaxSessionNameLength)
+}
+
+// truncateName cuts name to at most n characters, dropping any "-" left
+// at the end.
+func truncateName(name string, n int) string {
+	if r := []rune(name); len(r) > n {
+		return strings.TrimRight(string(r[:n]), "-")
+	}
+	return name
+}
+
+// UniqueSessionName returns base, or base-2, base-3, ... if base is taken.
+// base is shortened as needed to keep the name within MaxSessionNameLength.
+func UniqueSessionName(base string, existing []string) string {
+	taken := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		taken[name] = true
+	}
+	if !taken[base] {
+		return base
+	}
+	for n := 2; ; n++ {
+		suffix := fmt.Sprintf("-%d", n)
+		name := truncateName(base, MaxSessionNameLength-len(suffix)) + suffix
+		if !taken[name] {
+			return name
+		}
+	}
+}
+
+// SuggestSessionName suggests a free session name for a session started
+// in dir: "repo-branch" inside a git work tree (just "repo" on main or
+// master), otherwise the directory's base name.
+func SuggestSessionName(dir string, existing []string) string {
+	base := filepath.Base(dir)
+	if base == string(filepath.Separator) {
+		base = ""
+	}
+	if top, err := gitOutput(dir, "rev-parse", "--show-toplevel"); err == nil {
+		base = filepath.Base(top)
+		// Fails on a detached HEAD, but also works before the first commit
+		if branch, err := gitOutput(dir, "symbolic-ref", "--short", "HEAD"); err == nil {
+			switch branch {
+			case "main", "master":
+			default:
+				base += "-" + strings.ReplaceAll(branch, "/", "-")
+			}
+		}
+	}
+
+	base = SanitizeSessionName(base)
+	if base == "" {
+		base = "session"
+	}
+	return UniqueSessionName(base, existing)
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
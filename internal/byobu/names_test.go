@@ -0,0 +1,136 @@
+package byobu
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestValidateSessionName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"api", false},
+		{"my session", false},
+		{"api-v2_x", false},
+		{"naïve-ü", false},
+		{strings.Repeat("é", MaxSessionNameLength), false},
+		{"", true},
+		{" api", true},
+		{"api ", true},
+		{"api.v2", true},
+		{"host:1", true},
+		{"a\tb", true},
+		{strings.Repeat("a", MaxSessionNameLength+1), true},
+		{strings.Repeat("é", MaxSessionNameLength+1), true},
+	}
+	for _, tt := range tests {
+		err := ValidateSessionName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateSessionName(%q) = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSanitizeSessionName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"api", "api"},
+		{"api.v2", "api-v2"},
+		{"host:1", "host-1"},
+		{"my repo", "my-repo"},
+		{".hidden", "hidden"},
+		{"...", ""},
+		{"a\x01b", "a-b"},
+		{strings.Repeat("a", 70), strings.Repeat("a", MaxSessionNameLength)},
+		{strings.Repeat("é", 70), strings.Repeat("é", MaxSessionNameLength)},
+		{strings.Repeat("a", 63) + ".b", strings.Repeat("a", 63)},
+	}
+	for _, tt := range tests {
+		got := SanitizeSessionName(tt.in)
+		if got != tt.want {
+			t.Errorf("SanitizeSessionName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if got != "" && ValidateSessionName(got) != nil {
+			t.Errorf("SanitizeSessionName(%q) = %q, which is not valid", tt.in, got)
+		}
+	}
+}
+
+func TestUniqueSessionName(t *testing.T) {
+	long := strings.Repeat("é", MaxSessionNameLength)
+	tests := []struct {
+		base     string
+		existing []string
+		want     string
+	}{
+		{"api", nil, "api"},
+		{"api", []string{"web"}, "api"},
+		{"api", []string{"api"}, "api-2"},
+		{"api", []string{"api", "api-2", "api-3"}, "api-4"},
+		{long, []string{long}, strings.Repeat("é", MaxSessionNameLength-2) + "-2"},
+	}
+	for _, tt := range tests {
+		got := UniqueSessionName(tt.base, tt.existing)
+		if got != tt.want {
+			t.Errorf("UniqueSessionName(%q, %q) = %q, want %q", tt.base, tt.existing, got, tt.want)
+		}
+		if n := utf8.RuneCountInString(got); n > MaxSessionNameLength {
+			t.Errorf("UniqueSessionName(%q) is %d characters long", tt.base, n)
+		}
+	}
+}
+
+func TestSuggestSessionName(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+		}
+	}
+	mkdir := func(path string) string {
+		t.Helper()
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	plain := mkdir(filepath.Join(root, "my.project"))
+	onMain := mkdir(filepath.Join(root, "app"))
+	git(onMain, "init", "-q", "-b", "main")
+	sub := mkdir(filepath.Join(onMain, "src", "pkg"))
+	onBranch := mkdir(filepath.Join(root, "web"))
+	git(onBranch, "init", "-q", "-b", "feature/login")
+
+	tests := []struct {
+		name     string
+		dir      string
+		existing []string
+		want     string
+	}{
+		{"plain directory", plain, nil, "my-project"},
+		{"main branch", onMain, nil, "app"},
+		{"subdirectory", sub, nil, "app"},
+		{"feature branch", onBranch, nil, "web-feature-login"},
+		{"taken", onMain, []string{"app"}, "app-2"},
+		{"nothing usable", "/", nil, "session"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestSessionName(tt.dir, tt.existing); got != tt.want {
+				t.Errorf("SuggestSessionName(%q) = %q, want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package byobu
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Option is a session option override. An empty Value with Unset set
+// removes the override so the global byobu setting applies again.
+type Option struct {
+	Name  string
+	Value string
+	Unset bool
+}
+
+// String renders the option as "name value" or "name (unset)".
+func (o Option) String() string {
+	if o.Unset {
+		return o.Name + " (unset)"
+	}
+	return o.Name + " " + o.Value
+}
+
+// ConfigureMinimalStatusBar sets a minimal status bar (date/time only) for a session.
+// This applies per-session configuration without modifying global byobu settings.
+func (c *DefaultClient) ConfigureMinimalStatusBar(sessionName string) error {
+	return c.SetOption(sessionName, "status-right", MinimalStatusRight)
+}
+
+// RestoreStatusBar removes the session's status-right override so it falls
+// back to the global byobu status bar.
+func (c *DefaultClient) RestoreStatusBar(sessionName string) error {
+	return c.UnsetOption(sessionName, "status-right")
+}
+
+// SetOption sets a session option without touching the global value.
+func (c *DefaultClient) SetOption(sessionName, name, value string) error {
+	return c.setOption(sessionName, name, value)
+}
+
+// UnsetOption removes a session option override.
+func (c *DefaultClient) UnsetOption(sessionName, name string) error {
+	return c.setOption(sessionName, "-u", name)
+}
+
+func (c *DefaultClient) setOption(sessionName string, args ...string) error {
+	cmd := exec.Command("byobu", append([]string{"set-option", "-t", sessionName}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if strings.Contains(errMsg, "can't find session") || strings.Contains(errMsg, "session not found") {
+			return fmt.Errorf("session '%s' not found", sessionName)
+		}
+		return fmt.Errorf("byobu set-option: %s", errMsg)
+	}
+	return nil
+}
+
+// SessionOptions returns the options overridden for a session (not the
+// inherited global ones).
+func (c *DefaultClient) SessionOptions(sessionName string) ([]Option, error) {
+	cmd := exec.Command("byobu", "show-options", "-t", sessionName)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if strings.Contains(errMsg, "can't find session") || strings.Contains(errMsg, "session not found") {
+			return nil, fmt.Errorf("session '%s' not found", sessionName)
+		}
+		return nil, fmt.Errorf("byobu show-options: %s", errMsg)
+	}
+
+	var options []Option
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		name, value, _ := strings.Cut(line, " ")
+		if name == "" {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		options = append(options, Option{Name: name, Value: value})
+	}
+	return options, nil
+}
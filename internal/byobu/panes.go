@@ -0,0 +1,141 @@
+package byobu
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Layouts are tmux's preset pane layouts, for SelectLayout.
+var Layouts = []string{"even-horizontal", "even-vertical", "main-horizontal", "main-vertical", "tiled"}
+
+// SplitPane splits a pane in two without switching to the new pane.
+func (c *DefaultClient) SplitPane(target string, opts SplitOptions) error {
+	direction := "-v"
+	if opts.Horizontal {
+		direction = "-h"
+	}
+	args := []string{"-d", direction, "-t", target}
+	if opts.StartDir != "" {
+		args = append(args, "-c", opts.StartDir)
+	}
+	if opts.Command != "" {
+		args = append(args, opts.Command)
+	}
+	return c.runTarget("split-window", target, args...)
+}
+
+// KillPane kills a pane. Killing a window's last pane kills the window.
+func (c *DefaultClient) KillPane(target string) error {
+	return c.runTarget("kill-pane", target, "-t", target)
+}
+
+// BreakPane moves a pane out of its window into a new window of its own
+// at the end of sessionName. Without a destination tmux would use the
+// session of the client running byoman.
+func (c *DefaultClient) BreakPane(target, sessionName string) error {
+	return c.runTarget("break-pane", target, "-d", "-s", target, "-t", sessionName+":")
+}
+
+// JoinPane moves a pane into another window, splitting that window's
+// active pane.
+func (c *DefaultClient) JoinPane(target, dstWindow string) error {
+	return c.runTarget("join-pane", target, "-d", "-s", target, "-t", dstWindow)
+}
+
+// ZoomPane toggles a pane filling its whole window.
+func (c *DefaultClient) ZoomPane(target string) error {
+	return c.runTarget("resize-pane", target, "-Z", "-t", target)
+}
+
+// SelectLayout arranges a window's panes in one of the preset Layouts.
+func (c *DefaultClient) SelectLayout(window, layout string) error {
+	return c.runTarget("select-layout", window, "-t", window, layout)
+}
+
+// SendKeys types keys literally into the target pane without attaching.
+// Target may be a session name, window ("session:index" or "@id") or pane
+// ("%id"); for sessions and windows the active pane receives the keys.
+// When enter is true, Enter is pressed after the keys.
+func (c *DefaultClient) SendKeys(target, keys string, enter bool) error {
+	var args []string
+	if keys != "" {
+		args = append(args, "send-keys", "-t", target, "-l", "--", keys)
+	}
+	if enter {
+		if len(args) > 0 {
+			args = append(args, ";")
+		}
+		args = append(args, "send-keys", "-t", target, "Enter")
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	cmd := exec.Command("byobu", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if strings.Contains(errMsg, "can't find") || strings.Contains(errMsg, "not found") {
+			return fmt.Errorf("target '%s' not found", target)
+		}
+		return fmt.Errorf("byobu send-keys: %s", errMsg)
+	}
+	return nil
+}
+
+// CapturePane returns the visible rows of a pane plus up to lines rows of
+// scrollback. Wrapped lines are left split so that row numbers stay exact.
+func (c *DefaultClient) CapturePane(target string, lines int) (Capture, error) {
+	// Both commands run in one go, so the history can't grow in between
+	cmd := exec.Command("byobu", "display-message", "-p", "-t", target, "#{history_size}", ";",
+		"capture-pane", "-p", "-t", target, "-S", strconv.Itoa(-lines))
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if strings.Contains(errMsg, "can't find") || strings.Contains(errMsg, "not found") {
+			return Capture{}, fmt.Errorf("target '%s' not found", target)
+		}
+		return Capture{}, fmt.Errorf("byobu capture-pane: %s", errMsg)
+	}
+
+	size, text, _ := strings.Cut(stdout.String(), "\n")
+	history, err := strconv.Atoi(size)
+	if err != nil {
+		return Capture{}, fmt.Errorf("byobu capture-pane: unexpected history size '%s'", size)
+	}
+	return Capture{
+		Lines: strings.Split(strings.TrimSuffix(text, "\n"), "\n"),
+		First: history - min(lines, history),
+	}, nil
+}
+
+// PipePane pipes a pane's output to a shell command's stdin, replacing any
+// existing pipe. An empty command stops piping.
+func (c *DefaultClient) PipePane(target, command string) error {
+	args := []string{"pipe-pane", "-t", target}
+	if command != "" {
+		args = append(args, "-O", command)
+	}
+
+	cmd := exec.Command("byobu", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if strings.Contains(errMsg, "can't find") || strings.Contains(errMsg, "not found") {
+			return fmt.Errorf("target '%s' not found", target)
+		}
+		return fmt.Errorf("byobu pipe-pane: %s", errMsg)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package byobu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Profile is a named set of session options applied together.
+type Profile struct {
+	Name        string
+	Description string
+	Options     []Option
+}
+
+// DefaultProfile is applied to sessions created by byoman.
+const DefaultProfile = "mobile"
+
+// builtinProfiles are always available.
+var builtinProfiles = []Profile{
+	{
+		Name:        "mobile",
+		Description: "minimal status bar, mouse on, larger scrollback",
+		Options: []Option{
+			{Name: "status-right", Value: MinimalStatusRight},
+			{Name: "mouse", Value: "on"},
+			{Name: "history-limit", Value: "50000"},
+		},
+	},
+	{
+		Name:        "desktop",
+		Description: "full status bar, mouse on",
+		Options: []Option{
+			{Name: "status-right", Unset: true},
+			{Name: "mouse", Value: "on"},
+			{Name: "history-limit", Unset: true},
+		},
+	},
+	{
+		Name:        "byobu",
+		Description: "remove all byoman overrides",
+		Options: []Option{
+			{Name: "status-right", Unset: true},
+			{Name: "mouse", Unset: true},
+			{Name: "history-limit", Unset: true},
+		},
+	},
+}
+
+// Profiles returns the built-in profiles.
+func Profiles() []Profile {
+	return append([]Profile(nil), builtinProfiles...)
+}
+
+// FindProfile returns the profile with the given name from profiles.
+func FindProfile(profiles []Profile, name string) (Profile, error) {
+	for _, p := range profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name
+	}
+	return Profile{}, fmt.Errorf("unknown profile '%s' (available: %s)", name, strings.Join(names, ", "))
+}
+
+// ApplyProfile sets or unsets every option in the profile for a session.
+// tmux reads history-limit when a pane is created, so existing panes keep
+// their scrollback size.
+func (c *DefaultClient) ApplyProfile(sessionName string, profile Profile) error {
+	for _, o := range profile.Options {
+		var err error
+		if o.Unset {
+			err = c.UnsetOption(sessionName, o.Name)
+		} else {
+			err = c.SetOption(sessionName, o.Name, o.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("profile '%s': %w", profile.Name, err)
+		}
+	}
+	return nil
+}
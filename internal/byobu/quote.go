@@ -0,0 +1,26 @@
+package byobu
+
+import "strings"
+
+// ShellJoin turns command-line arguments back into one shell command. A
+// single argument is taken as a whole command line and kept as is; with
+// several, each is quoted so it reaches the shell as one word.
+func ShellJoin(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quoteArg(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// quoteArg single-quotes s for sh if it contains anything but plain
+// path characters.
+func quoteArg(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./%+=,@") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
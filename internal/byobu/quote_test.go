@@ -0,0 +1,43 @@
+package byobu
+
+import "testing"
+
+func TestQuoteArg(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"api", "api"},
+		{"/tmp/tmux-1000/default", "/tmp/tmux-1000/default"},
+		{"=api", "=api"},
+		{"a,b@c%d+e", "a,b@c%d+e"},
+		{"", "''"},
+		{"my session", "'my session'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME", "'$HOME'"},
+		{"a;b", "'a;b'"},
+		{"naïve", "'naïve'"},
+	}
+	for _, tt := range tests {
+		if got := quoteArg(tt.in); got != tt.want {
+			t.Errorf("quoteArg(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"echo $HOME | wc -c"}, "echo $HOME | wc -c"},
+		{[]string{"npm", "run", "dev"}, "npm run dev"},
+		{[]string{"git", "commit", "-m", "fix build"}, "git commit -m 'fix build'"},
+		{[]string{"echo", "it's", ""}, `echo 'it'\''s' ''`},
+	}
+	for _, tt := range tests {
+		if got := ShellJoin(tt.args); got != tt.want {
+			t.Errorf("ShellJoin(%q) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package byobu
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ShareInfo is what another local user needs to view a session.
+//
+// The session's own server is not opened up: tmux refuses a socket
+// directory that others can access. Instead the owner starts a second
+// server on a socket in a directory of its own, running a read-only client
+// of the session, and lets the other user attach to that server.
+type ShareInfo struct {
+	Session string
+	Server  string // Socket of the session's tmux server
+	Dir     string // Directory holding the sharing server's socket
+	Version string // tmux version, e.g. "3.3a"
+}
+
+// Socket returns the socket path of the sharing server.
+func (s ShareInfo) Socket() string {
+	return filepath.Join(s.Dir, "socket")
+}
+
+// ShareInfo looks up the server socket and tmux version of a session.
+func (c *DefaultClient) ShareInfo(sessionName string) (ShareInfo, error) {
+	// display-message falls back to the current session for a bad target
+	if err := c.runTarget("has-session", sessionName, "-t", sessionName); err != nil {
+		return ShareInfo{}, err
+	}
+	cmd := exec.Command("byobu", "display-message", "-p", "-t", sessionName, "#{socket_path}\t#{version}")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return ShareInfo{}, fmt.Errorf("byobu display-message: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	socket, version, _ := strings.Cut(strings.TrimSpace(stdout.String()), "\t")
+	return ShareInfo{
+		Session: sessionName,
+		Server:  socket,
+		Dir:     shareDir(sessionName),
+		Version: version,
+	}, nil
+}
+
+// shareDir returns the directory for sharing a session, unique per owner
+// and session.
+func shareDir(sessionName string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < 0x80 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return r
+		}
+		return '_'
+	}, sessionName)
+	return filepath.Join(os.TempDir(), fmt.Sprintf("byoman-share-%d-%s", os.Getuid(), name))
+}
+
+// HasServerAccess reports whether the server grants access per user
+// (tmux 3.3 and later). Older servers let anyone who can open the socket
+// take full control, whatever -r they attach with.
+func (s ShareInfo) HasServerAccess() bool {
+	major, rest, _ := strings.Cut(s.Version, ".")
+	minor := strings.TrimRightFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+	ma, err := strconv.Atoi(major)
+	if err != nil {
+		return false
+	}
+	mi, _ := strconv.Atoi(minor)
+	return ma > 3 || ma == 3 && mi >= 3
+}
+
+// Steps returns the commands that let user view the session read-only:
+// the owner runs the setup commands, then the user runs attach. An empty
+// user is shown as USER.
+func (s ShareInfo) Steps(user string) (setup []string, attach string) {
+	if user == "" {
+		user = "USER"
+	}
+	socket := quoteArg(s.Socket())
+	view := fmt.Sprintf("env -u TMUX tmux -S %s attach-session -r -t %s", quoteArg(s.Server), quoteArg("="+s.Session))
+	setup = []string{
+		// Others may reach the socket, but not list the directory
+		"mkdir -m 711 " + quoteArg(s.Dir),
+		fmt.Sprintf("tmux -S %s new-session -d -s %s %s", socket, quoteArg(s.Session), quoteArg(view)),
+		fmt.Sprintf("tmux -S %s set-option -g status off", socket),
+		"chmod o+rw " + socket,
+	}
+	if s.HasServerAccess() {
+		setup = append(setup, fmt.Sprintf("tmux -S %s server-access -a -r %s", socket, quoteArg(user)))
+	}
+	return setup, fmt.Sprintf("tmux -S %s attach-session -r", socket)
+}
+
+// Revoke returns the commands that stop sharing and remove everything the
+// setup commands created.
+func (s ShareInfo) Revoke() []string {
+	return []string{
+		fmt.Sprintf("tmux -S %s kill-server", quoteArg(s.Socket())),
+		"rm -r " + quoteArg(s.Dir),
+	}
+}
+
+// Instructions explains how to let user view the session read-only, one
+// line per element, ready to print.
+func (s ShareInfo) Instructions(user string) []string {
+	setup, attach := s.Steps(user)
+	who := user
+	if who == "" {
+		who = "USER"
+	}
+	lines := []string{
+		fmt.Sprintf("To let %s view '%s' read-only, start a separate tmux server", who, s.Session),
+		"that shows the session, on a socket they can open:", "",
+	}
+	for _, step := range setup {
+		lines = append(lines, "  "+step)
+	}
+	lines = append(lines, "", fmt.Sprintf("Then %s runs:", who), "", "  "+attach, "")
+	if !s.HasServerAccess() {
+		lines = append(lines,
+			fmt.Sprintf("Warning: tmux %s has no per-user access control. Anyone who can", s.Version),
+			"open the socket gets full control of the sharing server, including", "shells running as you; read-only is only by agreement.", "")
+	}
+	lines = append(lines, "To stop sharing and remove the socket:", "")
+	for _, step := range s.Revoke() {
+		lines = append(lines, "  "+step)
+	}
+	return lines
+}
@@ -0,0 +1,119 @@
+package byobu
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHasServerAccess(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"3.3", true},
+		{"3.3a", true},
+		{"3.4", true},
+		{"3.10", true},
+		{"4.0", true},
+		{"next-3.4", false},
+		{"3.2a", false},
+		{"2.9", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := (ShareInfo{Version: tt.version}).HasServerAccess(); got != tt.want {
+			t.Errorf("HasServerAccess(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestShareSteps(t *testing.T) {
+	info := ShareInfo{
+		Session: "my api",
+		Server:  "/tmp/tmux-1000/default",
+		Dir:     "/tmp/byoman-share-1000-my_api",
+		Version: "3.3a",
+	}
+	socket := "/tmp/byoman-share-1000-my_api/socket"
+	tests := []struct {
+		name    string
+		info    ShareInfo
+		user    string
+		want    []string
+		wantNot []string
+	}{
+		{
+			name: "with server access",
+			info: info,
+			user: "alice",
+			want: []string{
+				"mkdir -m 711 /tmp/byoman-share-1000-my_api",
+				"tmux -S " + socket + " new-session -d -s 'my api' 'env -u TMUX tmux -S /tmp/tmux-1000/default attach-session -r -t '\\''=my api'\\'''",
+				"chmod o+rw " + socket,
+				"tmux -S " + socket + " server-access -a -r alice",
+			},
+		},
+		{
+			name: "placeholder user",
+			info: info,
+			want: []string{"tmux -S " + socket + " server-access -a -r USER"},
+		},
+		{
+			name:    "old tmux",
+			info:    ShareInfo{Session: "api", Server: info.Server, Dir: "/tmp/s", Version: "3.2a"},
+			user:    "alice",
+			want:    []string{"chmod o+rw /tmp/s/socket"},
+			wantNot: []string{"server-access"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup, attach := tt.info.Steps(tt.user)
+			for _, w := range tt.want {
+				if !slices.Contains(setup, w) {
+					t.Errorf("setup is missing %q:\n%s", w, strings.Join(setup, "\n"))
+				}
+			}
+			for _, step := range setup {
+				for _, w := range tt.wantNot {
+					if strings.Contains(step, w) {
+						t.Errorf("unexpected step %q", step)
+					}
+				}
+				// The session's own server must stay private
+				if strings.Contains(step, "chmod") && strings.Contains(step, tt.info.Server) {
+					t.Errorf("step changes the permissions of the session's server: %q", step)
+				}
+			}
+			if want := "tmux -S " + tt.info.Socket() + " attach-session -r"; attach != want {
+				t.Errorf("attach = %q, want %q", attach, want)
+			}
+		})
+	}
+}
+
+func TestShareRevokeUndoesSetup(t *testing.T) {
+	info := ShareInfo{Session: "api", Server: "/tmp/tmux-1000/default", Dir: "/tmp/s", Version: "3.3a"}
+	revoke := info.Revoke()
+	want := []string{"tmux -S /tmp/s/socket kill-server", "rm -r /tmp/s"}
+	if !slices.Equal(revoke, want) {
+		t.Errorf("Revoke() = %q, want %q", revoke, want)
+	}
+	lines := strings.Join(info.Instructions("alice"), "\n")
+	for _, step := range revoke {
+		if !strings.Contains(lines, step) {
+			t.Errorf("instructions don't mention %q", step)
+		}
+	}
+}
+
+func TestShareDir(t *testing.T) {
+	for _, name := range []string{"api", "my api", "a/b", "../x", "é"} {
+		dir := shareDir(name)
+		base := dir[strings.LastIndex(dir, "/")+1:]
+		if !strings.HasPrefix(base, "byoman-share-") || strings.ContainsAny(base, " /.é") {
+			t.Errorf("shareDir(%q) = %q", name, dir)
+		}
+	}
+}
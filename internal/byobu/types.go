@@ -2,16 +2,25 @@ package byobu
 
 import "time"
 
+// MinimalStatusRight is the status-right used for the minimal (mobile)
+// status bar: just time and date.
+const MinimalStatusRight = "%H:%M %d-%b"
+
 // Session represents a byobu session.
 type Session struct {
 	Name         string    // Unique identifier (byobu session name)
 	ID           string    // Internal session ID (e.g., "$0")
 	Created      time.Time // When session was created
 	LastAttached time.Time // Last time a client attached
+	Activity     time.Time // Last activity in any of the session's windows
 	Attached     int       // Number of attached clients (0 = detached)
 	WindowCount  int       // Number of windows in session
 	Windows      []Window  // Window details (optional, loaded on demand)
 	Commands     []string  // Running commands across all panes
+	Group        string    // Session group name, if grouped
+	Grouped      bool      // Shares its windows with other sessions in a group?
+	Throwaway    bool      // Destroyed when its last client detaches (destroy-unattached)
+	StatusRight  string    // Effective status-right option
 }
 
 // IsDetached returns true if no clients are attached.
@@ -19,6 +28,11 @@ func (s Session) IsDetached() bool {
 	return s.Attached == 0
 }
 
+// HasMinimalStatusBar returns true if the minimal status bar is applied.
+func (s Session) HasMinimalStatusBar() bool {
+	return s.StatusRight == MinimalStatusRight
+}
+
 // Status returns "attached" or "detached" string.
 func (s Session) Status() string {
 	if s.Attached > 0 {
@@ -27,21 +41,114 @@ func (s Session) Status() string {
 	return "detached"
 }
 
+// HasActivity returns true if any window has unseen activity.
+func (s Session) HasActivity() bool {
+	for _, w := range s.Windows {
+		if w.ActivityFlag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasBell returns true if any window has rung the bell since last viewed.
+func (s Session) HasBell() bool {
+	for _, w := range s.Windows {
+		if w.BellFlag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSilence returns true if any window has gone silent (monitor-silence).
+func (s Session) HasSilence() bool {
+	for _, w := range s.Windows {
+		if w.SilenceFlag {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSessionOptions are optional settings for a new session. Sessions are
+// always created detached; attaching is up to the caller.
+type NewSessionOptions struct {
+	StartDir   string   // Working directory for the first window (default: byobu's)
+	WindowName string   // Name of the first window (default: its command)
+	Command    string   // Shell command run in the first window instead of a shell
+	Env        []string // KEY=VALUE pairs set in the session environment
+	GroupWith  string   // Join this session's group, sharing its windows
+}
+
+// AttachOptions are optional settings for attaching to a session.
+type AttachOptions struct {
+	DetachOthers bool // Detach the session's other clients (-d)
+	Throwaway    bool // Destroy the session once its last client detaches
+	ReadOnly     bool // Attach without being able to type into the session (-r)
+}
+
+// AttachedClient is a terminal attached to a session.
+type AttachedClient struct {
+	SessionName string    // Session the client is attached to
+	TTY         string    // Client terminal (e.g., "/dev/pts/3"), identifies the client
+	PID         int       // PID of the client process
+	Width       int       // Terminal width in columns
+	Height      int       // Terminal height in lines
+	TermName    string    // Terminal type (e.g., "xterm-256color")
+	User        string    // User running the client
+	Created     time.Time // When the client attached
+	Activity    time.Time // Last input from the client
+	ReadOnly    bool      // Attached read-only?
+}
+
 // Window represents a window within a session.
 type Window struct {
-	Index     int    // Window index within session (0-based)
-	Name      string // Window name
-	ID        string // Internal window ID (e.g., "@0")
-	PaneCount int    // Number of panes
-	Active    bool   // Is this the active window?
-	Panes     []Pane // Pane details
+	SessionName  string    // Session the window belongs to
+	Index        int       // Window index within session (0-based)
+	Name         string    // Window name
+	ID           string    // Internal window ID (e.g., "@0")
+	PaneCount    int       // Number of panes
+	Active       bool      // Is this the active window?
+	ActivityFlag bool      // Unseen activity (requires monitor-activity)
+	BellFlag     bool      // Bell rang since the window was last viewed
+	SilenceFlag  bool      // Silent for monitor-silence seconds
+	Activity     time.Time // Last activity in the window
+	Linked       bool      // Is the window linked into more than one session?
+	Panes        []Pane    // Pane details
+}
+
+// NewWindowOptions are optional settings for a new window.
+type NewWindowOptions struct {
+	Name     string // Window name (default: its command)
+	StartDir string // Working directory (default: the session's)
+	Command  string // Shell command run instead of a shell
 }
 
 // Pane represents a terminal pane within a window.
 type Pane struct {
+	SessionName    string // Session the pane belongs to
+	WindowIndex    int    // Index of the containing window
+	WindowID       string // Internal ID of the containing window (e.g., "@0")
 	Index          int    // Pane index within window (0-based)
 	ID             string // Internal pane ID (e.g., "%0")
+	PID            int    // PID of the pane's initial process (usually the shell)
 	CurrentCommand string // Foreground process (e.g., "vim", "zsh")
 	CurrentPath    string // Working directory
 	Active         bool   // Is this the active pane?
+	Piped          bool   // Is output being piped (pipe-pane), e.g. to a log?
+	Zoomed         bool   // Is this pane zoomed to fill its window?
+}
+
+// Capture is the text of a pane returned by CapturePane.
+type Capture struct {
+	Lines []string // One per screen row, oldest first
+	First int      // Row number of Lines[0], counting from the oldest row in the history
+}
+
+// SplitOptions are optional settings for splitting a pane.
+type SplitOptions struct {
+	Horizontal bool   // Side by side instead of stacked
+	StartDir   string // Working directory (default: the session's)
+	Command    string // Shell command run instead of a shell
 }
@@ -0,0 +1,70 @@
+package byobu
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// NewWindow adds a window at the end of a session without switching to it.
+func (c *DefaultClient) NewWindow(sessionName string, opts NewWindowOptions) error {
+	args := []string{"-d", "-t", sessionName + ":"}
+	if opts.Name != "" {
+		args = append(args, "-n", opts.Name)
+	}
+	if opts.StartDir != "" {
+		args = append(args, "-c", opts.StartDir)
+	}
+	if opts.Command != "" {
+		args = append(args, opts.Command)
+	}
+	return c.runTarget("new-window", sessionName, args...)
+}
+
+// RenameWindow renames a window. Target is a window ID or session:index.
+func (c *DefaultClient) RenameWindow(target, name string) error {
+	if name == "" {
+		return fmt.Errorf("window name cannot be empty")
+	}
+	return c.runTarget("rename-window", target, "-t", target, name)
+}
+
+// KillWindow kills a window and its panes, removing it from every session
+// it is linked into.
+func (c *DefaultClient) KillWindow(target string) error {
+	return c.runTarget("kill-window", target, "-t", target)
+}
+
+// MoveWindow moves a window to the end of another session.
+func (c *DefaultClient) MoveWindow(target, dstSession string) error {
+	return c.runTarget("move-window", target, "-d", "-s", target, "-t", dstSession+":")
+}
+
+// SwapWindow swaps the positions of two windows, e.g. to reorder them.
+func (c *DefaultClient) SwapWindow(target, other string) error {
+	return c.runTarget("swap-window", target, "-d", "-s", target, "-t", other)
+}
+
+// LinkWindow links a window into another session as well, so both
+// sessions show the same window.
+func (c *DefaultClient) LinkWindow(target, dstSession string) error {
+	return c.runTarget("link-window", target, "-d", "-s", target, "-t", dstSession+":")
+}
+
+// runTarget runs a byobu command acting on target, reporting a missing
+// target by name.
+func (c *DefaultClient) runTarget(command, target string, args ...string) error {
+	cmd := exec.Command("byobu", append([]string{command}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if strings.Contains(errMsg, "can't find") || strings.Contains(errMsg, "not found") {
+			return fmt.Errorf("target '%s' not found", target)
+		}
+		return fmt.Errorf("byobu %s: %s", command, errMsg)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Package config loads byoman's optional preferences file.
+//
+// The file is plain "name = value" lines; blank lines and lines starting
+// with # are ignored. Without a file byoman behaves as if it were empty.
+package config
+
+import (
+	"bufio"
+	"byoman/internal/byobu"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Sorts are the accepted values of the sort setting.
+var Sorts = []string{"name", "cpu", "memory", "activity"}
+
+// Columns are the optional columns of the session list, in display order.
+var Columns = []string{"windows", "status", "usage", "commands"}
+
+// MinRefresh is the shortest accepted refresh interval; byoman runs several
+// byobu commands per refresh.
+const MinRefresh = 500 * time.Millisecond
+
+// Config holds the user's preferences.
+type Config struct {
+	Refresh time.Duration // Auto-refresh period of the session list
+	Sort    string        // Initial sort order, one of Sorts
+	Columns []string      // Visible list columns, a subset of Columns
+	Profile string        // Option profile for new sessions, or "none"
+	Hook    string        // Shell command run per watcher event in the TUI
+
+	// Key bindings of the session list by action, e.g. "kill" -> ["K"].
+	// Actions are checked by the TUI, which defines them.
+	Keys map[string][]string
+
+	// Theme names the color theme: "auto" (by terminal background), a
+	// built-in theme or one of Themes.
+	Theme string
+	// Themes are user-defined themes by name, each mapping a color role
+	// (or "base", the built-in theme it starts from) to a value. They are
+	// checked by the TUI, which defines the roles.
+	Themes map[string]map[string]string
+
+	// Commands are user-defined command palette entries, in file order.
+	Commands []Command
+}
+
+// Command is a shell command offered in the command palette.
+type Command struct {
+	Name string // Title shown in the palette
+	Run  string // Run with sh -c
+}
+
+// Default returns the settings used when there is no config file.
+func Default() Config {
+	return Config{
+		Refresh: 3 * time.Second,
+		Sort:    "name",
+		Columns: []string{"windows", "status", "commands"},
+		Profile: byobu.DefaultProfile,
+		Theme:   "auto",
+	}
+}
+
+// Path returns the config file path, $XDG_CONFIG_HOME/byoman/config
+// (default ~/.config/byoman/config).
+func Path() (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locate home directory: %w", err)
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "byoman", "config"), nil
+}
+
+// Load reads the config file. A missing file yields Default().
+func Load() (Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Config{}, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	defer f.Close()
+	return Parse(f, path)
+}
+
+// Parse reads settings from r on top of the defaults. name is used in
+// error messages, which point at the offending line.
+func Parse(r io.Reader, name string) (Config, error) {
+	cfg := Default()
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Config{}, fmt.Errorf("%s:%d: expected 'name = value'", name, n)
+		}
+		if err := cfg.set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return Config{}, fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return cfg, nil
+}
+
+// set applies one setting.
+func (c *Config) set(key, value string) error {
+	switch key {
+	case "refresh":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("refresh: %w", err)
+		}
+		if d < MinRefresh {
+			return fmt.Errorf("refresh: must be at least %s", MinRefresh)
+		}
+		c.Refresh = d
+	case "sort":
+		if !slices.Contains(Sorts, value) {
+			return fmt.Errorf("sort: unknown order '%s' (available: %s)", value, strings.Join(Sorts, ", "))
+		}
+		c.Sort = value
+	case "columns":
+		c.Columns = nil
+		for _, col := range splitList(value) {
+			if !slices.Contains(Columns, col) {
+				return fmt.Errorf("columns: unknown column '%s' (available: %s)", col, strings.Join(Columns, ", "))
+			}
+			c.Columns = append(c.Columns, col)
+		}
+	case "profile":
+		if value != "none" {
+			if _, err := byobu.FindProfile(byobu.Profiles(), value); err != nil {
+				return err
+			}
+		}
+		c.Profile = value
+	case "hook":
+		c.Hook = value
+	case "key.":
+		return fmt.Errorf("key: missing action name")
+	case "theme":
+		if value == "" {
+			return fmt.Errorf("theme: missing name")
+		}
+		c.Theme = value
+	default:
+		if name, ok := strings.CutPrefix(key, "command."); ok {
+			if name == "" || value == "" {
+				return fmt.Errorf("expected command.<name> = shell command")
+			}
+			c.Commands = slices.DeleteFunc(c.Commands, func(cmd Command) bool { return cmd.Name == name })
+			c.Commands = append(c.Commands, Command{Name: name, Run: value})
+			return nil
+		}
+		if rest, ok := strings.CutPrefix(key, "theme."); ok {
+			i := strings.LastIndex(rest, ".")
+			if i <= 0 || i == len(rest)-1 {
+				return fmt.Errorf("expected theme.<name>.<color> = value")
+			}
+			if c.Themes == nil {
+				c.Themes = make(map[string]map[string]string)
+			}
+			if c.Themes[rest[:i]] == nil {
+				c.Themes[rest[:i]] = make(map[string]string)
+			}
+			c.Themes[rest[:i]][rest[i+1:]] = value
+			return nil
+		}
+		if action, ok := strings.CutPrefix(key, "key."); ok {
+			if c.Keys == nil {
+				c.Keys = make(map[string][]string)
+			}
+			c.Keys[action] = splitList(value)
+			return nil
+		}
+		return fmt.Errorf("unknown setting '%s'", key)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated value, dropping empty elements.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ShowColumn reports whether a list column is visible.
+func (c Config) ShowColumn(column string) bool {
+	return slices.Contains(c.Columns, column)
+}
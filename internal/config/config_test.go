@@ -0,0 +1,181 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(t *testing.T, c Config)
+	}{
+		{
+			name:  "empty file keeps defaults",
+			input: "",
+			check: func(t *testing.T, c Config) {
+				if !reflect.DeepEqual(c, Default()) {
+					t.Errorf("got %+v, want defaults", c)
+				}
+			},
+		},
+		{
+			name:  "comments and blank lines",
+			input: "# a comment\n\n   \n  # indented comment\nsort = cpu\n",
+			check: func(t *testing.T, c Config) {
+				if c.Sort != "cpu" {
+					t.Errorf("Sort = %q", c.Sort)
+				}
+			},
+		},
+		{
+			name:  "refresh",
+			input: "refresh = 5s",
+			check: func(t *testing.T, c Config) {
+				if c.Refresh != 5*time.Second {
+					t.Errorf("Refresh = %s", c.Refresh)
+				}
+			},
+		},
+		{
+			name:  "columns",
+			input: "columns = usage, , windows",
+			check: func(t *testing.T, c Config) {
+				if !reflect.DeepEqual(c.Columns, []string{"usage", "windows"}) {
+					t.Errorf("Columns = %q", c.Columns)
+				}
+				if !c.ShowColumn("usage") || c.ShowColumn("status") {
+					t.Errorf("ShowColumn wrong for %q", c.Columns)
+				}
+			},
+		},
+		{
+			name:  "no columns",
+			input: "columns =",
+			check: func(t *testing.T, c Config) {
+				if len(c.Columns) != 0 {
+					t.Errorf("Columns = %q", c.Columns)
+				}
+			},
+		},
+		{
+			name:  "profile",
+			input: "profile = desktop\n",
+			check: func(t *testing.T, c Config) {
+				if c.Profile != "desktop" {
+					t.Errorf("Profile = %q", c.Profile)
+				}
+			},
+		},
+		{
+			name:  "no profile",
+			input: "profile = none",
+			check: func(t *testing.T, c Config) {
+				if c.Profile != "none" {
+					t.Errorf("Profile = %q", c.Profile)
+				}
+			},
+		},
+		{
+			name:  "hook",
+			input: `hook = notify "$BYOMAN_SESSION = $BYOMAN_MESSAGE"`,
+			check: func(t *testing.T, c Config) {
+				if c.Hook != `notify "$BYOMAN_SESSION = $BYOMAN_MESSAGE"` {
+					t.Errorf("Hook = %q", c.Hook)
+				}
+			},
+		},
+		{
+			name:  "keys",
+			input: "key.kill = ctrl+k\nkey.exec = e, x\nkey.watch =\nkey.kill = K",
+			check: func(t *testing.T, c Config) {
+				want := map[string][]string{"kill": {"K"}, "exec": {"e", "x"}, "watch": nil}
+				if !reflect.DeepEqual(c.Keys, want) {
+					t.Errorf("Keys = %q, want %q", c.Keys, want)
+				}
+			},
+		},
+		{
+			name:  "theme",
+			input: "theme = mine\ntheme.mine.base = dark\ntheme.mine.primary = #00afff\ntheme.my.dotted.secondary = 245",
+			check: func(t *testing.T, c Config) {
+				if c.Theme != "mine" {
+					t.Errorf("Theme = %q", c.Theme)
+				}
+				want := map[string]map[string]string{
+					"mine":      {"base": "dark", "primary": "#00afff"},
+					"my.dotted": {"secondary": "245"},
+				}
+				if !reflect.DeepEqual(c.Themes, want) {
+					t.Errorf("Themes = %q, want %q", c.Themes, want)
+				}
+			},
+		},
+		{
+			name:  "commands keep file order and replace duplicates",
+			input: "command.Pull = git pull\ncommand.Copy name = printf %s \"$BYOMAN_SESSION\"\ncommand.Pull = git pull --rebase",
+			check: func(t *testing.T, c Config) {
+				want := []Command{
+					{Name: "Copy name", Run: `printf %s "$BYOMAN_SESSION"`},
+					{Name: "Pull", Run: "git pull --rebase"},
+				}
+				if !reflect.DeepEqual(c.Commands, want) {
+					t.Errorf("Commands = %+v, want %+v", c.Commands, want)
+				}
+			},
+		},
+		{
+			name:  "value with equals sign",
+			input: "command.Env = FOO=1 make",
+			check: func(t *testing.T, c Config) {
+				if len(c.Commands) != 1 || c.Commands[0].Run != "FOO=1 make" {
+					t.Errorf("Commands = %+v", c.Commands)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Parse(strings.NewReader(tt.input), "config")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string // Expected in the error message
+	}{
+		{"sort", "config:1: expected 'name = value'"},
+		{"\n\nbogus = 1", "config:3: unknown setting 'bogus'"},
+		{"refresh = soon", "config:1: refresh:"},
+		{"refresh = 100ms", "must be at least 500ms"},
+		{"sort = size", "unknown order 'size'"},
+		{"columns = windows, size", "unknown column 'size'"},
+		{"profile = tablet", "tablet"},
+		{"theme =", "theme: missing name"},
+		{"theme.mine = 1", "expected theme.<name>.<color>"},
+		{"theme.mine. = 1", "expected theme.<name>.<color>"},
+		{"theme..primary = 1", "expected theme.<name>.<color>"},
+		{"key. = x", "missing action name"},
+		{"command. = ls", "expected command.<name>"},
+		{"command.List =", "expected command.<name>"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.input), "config")
+		if err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", tt.input)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
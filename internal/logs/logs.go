@@ -0,0 +1,113 @@
+package logs
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMaxSize is the size at which a log file is rotated.
+const DefaultMaxSize = 10 << 20 // 10 MiB
+
+// keepRotated is how many rotated files (.1, .2, ...) are kept per log.
+const keepRotated = 3
+
+// Dir returns the log directory, $XDG_DATA_HOME/byoman/logs
+// (default ~/.local/share/byoman/logs).
+func Dir() (string, error) {
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locate home directory: %w", err)
+		}
+		base = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(base, "byoman", "logs"), nil
+}
+
+// PathFor returns a new timestamped log path for a pane, grouped by session:
+// <dir>/<session>/<session>-<window>.<pane>-YYYYmmdd-HHMMSS.log
+func PathFor(dir string, pane byobu.Pane, t time.Time) string {
+	session := sanitize(pane.SessionName)
+	name := fmt.Sprintf("%s-%d.%d-%s.log", session, pane.WindowIndex, pane.Index, t.Format("20060102-150405"))
+	return filepath.Join(dir, session, name)
+}
+
+// sanitize makes a session name safe to use as a file name.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == os.PathSeparator || r < 0x20 {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// File describes a log file on disk.
+type File struct {
+	Path    string
+	Session string // Session directory the log belongs to
+	Size    int64
+	ModTime time.Time
+}
+
+// Name returns the file name without the directory.
+func (f File) Name() string {
+	return filepath.Base(f.Path)
+}
+
+// List returns all log files (including rotated ones), newest first.
+// A missing log directory is not an error.
+func List(dir string) ([]File, error) {
+	sessions, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read log directory: %w", err)
+	}
+
+	var files []File
+	for _, s := range sessions {
+		if !s.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(dir, s.Name()))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			files = append(files, File{
+				Path:    filepath.Join(dir, s.Name(), e.Name()),
+				Session: s.Name(),
+				Size:    info.Size(),
+				ModTime: info.ModTime(),
+			})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime.After(files[j].ModTime)
+	})
+	return files, nil
+}
+
+// PipeCommand returns the pipe-pane shell command that runs
+// `<exe> pipe-log -max-size N <path>` to receive a pane's output.
+func PipeCommand(exe, path string, maxSize int64) string {
+	return fmt.Sprintf("exec %s pipe-log -max-size %d %s", shellQuote(exe), maxSize, shellQuote(path))
+}
+
+// shellQuote wraps s in single quotes for sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,174 @@
+package logs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// RotatingWriter appends to a file and rotates it once it exceeds MaxSize,
+// keeping a few previous generations as path.1, path.2, ...
+type RotatingWriter struct {
+	Path    string
+	MaxSize int64
+
+	file *os.File
+	size int64
+}
+
+// NewRotatingWriter opens (or creates) path for appending.
+func NewRotatingWriter(path string, maxSize int64) (*RotatingWriter, error) {
+	w := &RotatingWriter{Path: path, MaxSize: maxSize}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *RotatingWriter) open() error {
+	f, err := os.OpenFile(w.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat log: %w", err)
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// Write appends p, rotating first if the file is full.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	if w.MaxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.MaxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// rotate shifts path.N-1 -> path.N ... path -> path.1 and reopens path.
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	for i := keepRotated - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", w.Path, i), fmt.Sprintf("%s.%d", w.Path, i+1))
+	}
+	if err := os.Rename(w.Path, w.Path+".1"); err != nil {
+		return fmt.Errorf("rotate log: %w", err)
+	}
+	return w.open()
+}
+
+// Close closes the underlying file.
+func (w *RotatingWriter) Close() error {
+	return w.file.Close()
+}
+
+// CopyStripped copies r to w as output arrives, removing ANSI escape
+// sequences, carriage returns and other control characters so logs read as
+// plain text. Output without a trailing newline, such as a prompt, is
+// written right away; sequences split across reads are still removed.
+func CopyStripped(w io.Writer, r io.Reader) error {
+	var s stripper
+	buf := make([]byte, 4096)
+	out := make([]byte, 0, len(buf))
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			out = s.strip(out[:0], buf[:n])
+			if len(out) > 0 {
+				if _, werr := w.Write(out); werr != nil {
+					return werr
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Escape sequence states of a stripper.
+const (
+	stripText      = iota // Plain text
+	stripEscape           // After ESC
+	stripEscInter         // ESC followed by intermediate bytes
+	stripCSI              // Control sequence, ESC [
+	stripString           // OSC, DCS, SOS, PM or APC string
+	stripStringEsc        // ESC inside a string, maybe the terminator
+)
+
+// stripper removes escape sequences from a stream. It keeps its state
+// between calls, so a sequence may be split across chunks.
+type stripper struct {
+	state int
+}
+
+// strip appends the text of src to dst.
+func (s *stripper) strip(dst, src []byte) []byte {
+	for _, b := range src {
+		switch s.state {
+		case stripText:
+			switch {
+			case b == 0x1b:
+				s.state = stripEscape
+			case b == '\n' || b == '\t' || b >= 0x20 && b != 0x7f:
+				dst = append(dst, b)
+			}
+		case stripEscape:
+			s.escape(b)
+		case stripEscInter:
+			if b < 0x20 || b > 0x2f {
+				s.state = stripText
+			}
+		case stripCSI:
+			if b >= 0x40 && b <= 0x7e {
+				s.state = stripText
+			}
+		case stripString:
+			switch b {
+			case 0x07:
+				s.state = stripText
+			case 0x1b:
+				s.state = stripStringEsc
+			}
+		case stripStringEsc:
+			if b == '\\' {
+				s.state = stripText
+			} else {
+				s.escape(b) // ESC ends the string and starts a new sequence
+			}
+		}
+	}
+	return dst
+}
+
+// escape handles the byte after ESC.
+func (s *stripper) escape(b byte) {
+	switch {
+	case b == '[':
+		s.state = stripCSI
+	case b == ']' || b == 'P' || b == 'X' || b == '^' || b == '_':
+		s.state = stripString
+	case b == 0x1b:
+		s.state = stripEscape
+	case b >= 0x20 && b <= 0x2f:
+		s.state = stripEscInter
+	default:
+		s.state = stripText
+	}
+}
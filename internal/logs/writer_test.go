@@ -0,0 +1,67 @@
+package logs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCopyStripped(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"plain", "hello\nworld\n", "hello\nworld\n"},
+		{"no trailing newline", "$ ", "$ "},
+		{"colors", "\x1b[1;31mred\x1b[0m text\n", "red text\n"},
+		{"carriage return", "line\r\n", "line\n"},
+		{"osc title with bel", "\x1b]0;title\x07after", "after"},
+		{"osc with st", "\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"charset", "\x1b(Babc", "abc"},
+		{"keypad mode", "\x1b=x\x1b>", "x"},
+		{"private csi", "\x1b[?2004hprompt", "prompt"},
+		{"controls", "a\x07b\x08c\td", "abc\td"},
+		{"utf-8", "héllo ✓\n", "héllo ✓\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, split := range []bool{false, true} {
+				r := strings.NewReader(tt.in)
+				var out bytes.Buffer
+				var err error
+				if split {
+					// Sequences split across reads are still removed
+					err = CopyStripped(&out, iotest.OneByteReader(r))
+				} else {
+					err = CopyStripped(&out, r)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if out.String() != tt.want {
+					t.Errorf("CopyStripped(%q, split=%v) = %q, want %q", tt.in, split, out.String(), tt.want)
+				}
+			}
+		})
+	}
+}
+
+// chunkWriter records each write.
+type chunkWriter struct {
+	writes []string
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func TestCopyStrippedWritesPartialLines(t *testing.T) {
+	var w chunkWriter
+	if err := CopyStripped(&w, iotest.OneByteReader(strings.NewReader("ab"))); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.writes) != 2 {
+		t.Errorf("got writes %q, want one per read", w.writes)
+	}
+}
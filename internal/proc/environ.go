@@ -0,0 +1,30 @@
+package proc
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SSHClientAddr returns the address of the SSH client a process was
+// started from, taken from SSH_CONNECTION or SSH_CLIENT in its environment.
+// It returns "" for local processes and for processes of other users,
+// whose environment can't be read.
+func SSHClientAddr(pid int) string {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "environ"))
+	if err != nil {
+		return ""
+	}
+	for _, kv := range bytes.Split(data, []byte{0}) {
+		for _, name := range []string{"SSH_CONNECTION=", "SSH_CLIENT="} {
+			if value, ok := strings.CutPrefix(string(kv), name); ok {
+				if fields := strings.Fields(value); len(fields) > 0 {
+					return fields[0]
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package proc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// procRoot is the mount point of the proc filesystem.
+const procRoot = "/proc"
+
+// clockTicks is the kernel's USER_HZ, used to convert /proc tick counts to
+// seconds. It is 100 on every mainstream Linux architecture and can't be
+// queried without cgo.
+const clockTicks = 100
+
+// ErrUnsupported is returned when /proc is not available (e.g. macOS).
+var ErrUnsupported = errors.New("process information requires /proc (Linux only)")
+
+// Process is a snapshot of a single process read from /proc/<pid>/stat.
+type Process struct {
+	PID       int
+	PPID      int
+	Comm      string // Executable name (truncated to 15 chars by the kernel)
+	State     string // Single-letter state (R, S, D, Z, T, ...)
+	CPUTicks  uint64 // utime + stime, in clock ticks
+	StartTick uint64 // Start time after boot, in clock ticks
+	RSS       uint64 // Resident set size in bytes
+}
+
+// Table is a snapshot of all processes, indexed by PID.
+type Table struct {
+	Procs    map[int]Process
+	Children map[int][]int // PID -> child PIDs
+	Uptime   float64       // System uptime in seconds when the snapshot was taken
+}
+
+// ReadTable reads every process from /proc.
+func ReadTable() (*Table, error) {
+	uptime, err := readUptime()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", procRoot, err)
+	}
+
+	t := &Table{
+		Procs:    make(map[int]Process, len(entries)),
+		Children: make(map[int][]int),
+		Uptime:   uptime,
+	}
+
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue // Not a process directory
+		}
+		p, err := readStat(pid)
+		if err != nil {
+			continue // Process exited while scanning
+		}
+		t.Procs[pid] = p
+		t.Children[p.PPID] = append(t.Children[p.PPID], pid)
+	}
+
+	return t, nil
+}
+
+// Descendants returns pid and all of its descendants, parents before children.
+func (t *Table) Descendants(pid int) []int {
+	if _, ok := t.Procs[pid]; !ok {
+		return nil
+	}
+	result := []int{pid}
+	for i := 0; i < len(result); i++ {
+		result = append(result, t.Children[result[i]]...)
+	}
+	return result
+}
+
+// readUptime returns the system uptime in seconds.
+func readUptime() (float64, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, "uptime"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, ErrUnsupported
+		}
+		return 0, fmt.Errorf("read uptime: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("read uptime: unexpected format")
+	}
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+// readStat parses /proc/<pid>/stat.
+func readStat(pid int) (Process, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return Process{}, err
+	}
+	return parseStat(pid, data)
+}
+
+// parseStat parses the contents of /proc/<pid>/stat.
+func parseStat(pid int, data []byte) (Process, error) {
+	// comm is wrapped in parens and may itself contain spaces or parens,
+	// so split around the last ')'.
+	open := bytes.IndexByte(data, '(')
+	closing := bytes.LastIndexByte(data, ')')
+	if open < 0 || closing < open {
+		return Process{}, fmt.Errorf("malformed stat for pid %d", pid)
+	}
+
+	// Fields after comm, starting with state (field 3 in proc(5)).
+	fields := strings.Fields(string(data[closing+1:]))
+	if len(fields) < 22 {
+		return Process{}, fmt.Errorf("short stat for pid %d", pid)
+	}
+
+	ppid, _ := strconv.Atoi(fields[1])
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	start, _ := strconv.ParseUint(fields[19], 10, 64)
+	rssPages, _ := strconv.ParseUint(fields[21], 10, 64)
+
+	return Process{
+		PID:       pid,
+		PPID:      ppid,
+		Comm:      string(data[open+1 : closing]),
+		State:     fields[0],
+		CPUTicks:  utime + stime,
+		StartTick: start,
+		RSS:       rssPages * uint64(os.Getpagesize()),
+	}, nil
+}
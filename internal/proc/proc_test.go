@@ -0,0 +1,46 @@
+package proc
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseStat(t *testing.T) {
+	page := uint64(os.Getpagesize())
+	tests := []struct {
+		name    string
+		data    string
+		want    Process
+		wantErr bool
+	}{
+		{
+			name: "shell",
+			data: "1234 (bash) S 1000 1234 1234 34816 1240 4194304 1800 9000 0 3 12 5 20 7 20 0 1 0 56789 9000000 1300 18446744073709551615\n",
+			want: Process{PID: 1234, PPID: 1000, Comm: "bash", State: "S", CPUTicks: 17, StartTick: 56789, RSS: 1300 * page},
+		},
+		{
+			name: "comm with spaces and parens",
+			data: "42 (my (odd) name) R 1 42 42 0 -1 0 0 0 0 0 100 50 0 0 20 0 1 0 10 0 7 0",
+			want: Process{PID: 42, PPID: 1, Comm: "my (odd) name", State: "R", CPUTicks: 150, StartTick: 10, RSS: 7 * page},
+		},
+		{name: "no comm", data: "42 bash S 1", wantErr: true},
+		{name: "short", data: "42 (bash) S 1 42 42", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStat(tt.want.PID, []byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseStat() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseStat() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package proc
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Node is a process within a tree, with details for display.
+type Node struct {
+	Process
+	Depth   int           // Distance from the tree root (root = 0)
+	Cmdline string        // Full command line, or "[comm]" for kernel threads
+	Elapsed time.Duration // Time since the process started
+}
+
+// Tree returns pid and its descendants in depth-first order.
+func (t *Table) Tree(pid int) []Node {
+	var nodes []Node
+	var walk func(pid, depth int)
+	walk = func(pid, depth int) {
+		p, ok := t.Procs[pid]
+		if !ok {
+			return
+		}
+		elapsed := t.Uptime - float64(p.StartTick)/clockTicks
+		nodes = append(nodes, Node{
+			Process: p,
+			Depth:   depth,
+			Cmdline: readCmdline(p),
+			Elapsed: time.Duration(elapsed * float64(time.Second)),
+		})
+		for _, child := range t.Children[pid] {
+			walk(child, depth+1)
+		}
+	}
+	walk(pid, 0)
+	return nodes
+}
+
+// ReadTree reads the process tree rooted at pid.
+func ReadTree(pid int) ([]Node, error) {
+	table, err := ReadTable()
+	if err != nil {
+		return nil, err
+	}
+	nodes := table.Tree(pid)
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("process %d not found", pid)
+	}
+	return nodes, nil
+}
+
+// Signal sends sig to the process.
+func Signal(pid int, sig syscall.Signal) error {
+	if err := syscall.Kill(pid, sig); err != nil {
+		return fmt.Errorf("send %s to %d: %w", SignalName(sig), pid, err)
+	}
+	return nil
+}
+
+// SignalName returns the conventional name of sig (e.g. "SIGTERM").
+func SignalName(sig syscall.Signal) string {
+	switch sig {
+	case syscall.SIGINT:
+		return "SIGINT"
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	case syscall.SIGKILL:
+		return "SIGKILL"
+	case syscall.SIGHUP:
+		return "SIGHUP"
+	default:
+		return "signal " + strconv.Itoa(int(sig))
+	}
+}
+
+// readCmdline returns the command line of p with arguments space-separated.
+func readCmdline(p Process) string {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(p.PID), "cmdline"))
+	if err != nil || len(data) == 0 {
+		return "[" + p.Comm + "]"
+	}
+	data = bytes.TrimRight(data, "\x00")
+	return string(bytes.ReplaceAll(data, []byte{0}, []byte{' '}))
+}
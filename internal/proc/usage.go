@@ -0,0 +1,72 @@
+package proc
+
+import (
+	"sync"
+	"time"
+)
+
+// Usage is the aggregated resource usage of a process tree.
+type Usage struct {
+	CPU float64 // CPU usage in percent of one core
+	RSS uint64  // Resident memory in bytes
+}
+
+// Add accumulates other into u.
+func (u *Usage) Add(other Usage) {
+	u.CPU += other.CPU
+	u.RSS += other.RSS
+}
+
+// Collector samples CPU and memory usage of process trees.
+// CPU% is computed from the tick delta between consecutive samples, so a
+// Collector should be kept for the lifetime of the program.
+type Collector struct {
+	mu     sync.Mutex
+	prev   map[int]uint64 // PID -> CPU ticks at last sample
+	prevAt time.Time
+}
+
+// NewCollector creates a new usage collector.
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Sample returns the usage of each root PID's process tree.
+// Processes seen for the first time report their average CPU since start.
+func (c *Collector) Sample(roots []int) (map[int]Usage, error) {
+	table, err := ReadTable()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(c.prevAt).Seconds()
+
+	cpu := make(map[int]float64, len(table.Procs))
+	ticks := make(map[int]uint64, len(table.Procs))
+	for pid, p := range table.Procs {
+		ticks[pid] = p.CPUTicks
+		if last, ok := c.prev[pid]; ok && elapsed > 0 && p.CPUTicks >= last {
+			cpu[pid] = float64(p.CPUTicks-last) / clockTicks / elapsed * 100
+			continue
+		}
+		if lifetime := table.Uptime - float64(p.StartTick)/clockTicks; lifetime > 0 {
+			cpu[pid] = float64(p.CPUTicks) / clockTicks / lifetime * 100
+		}
+	}
+	c.prev = ticks
+	c.prevAt = now
+
+	result := make(map[int]Usage, len(roots))
+	for _, root := range roots {
+		var u Usage
+		for _, pid := range table.Descendants(root) {
+			u.Add(Usage{CPU: cpu[pid], RSS: table.Procs[pid].RSS})
+		}
+		result[root] = u
+	}
+	return result, nil
+}
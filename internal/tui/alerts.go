@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"byoman/internal/watch"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordAlerts counts rule matches per session for the list badge.
+func (m *Model) recordAlerts(events []watch.Event) {
+	for _, e := range events {
+		if e.Kind == watch.EventMatch {
+			m.alerts[e.Session]++
+		}
+	}
+}
+
+func (m Model) handleAlertRulesState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rules := m.matcher.Rules()
+	switch msg.String() {
+	case "up", "k":
+		if m.ruleCursor > 0 {
+			m.ruleCursor--
+		}
+	case "down", "j":
+		if m.ruleCursor < len(rules)-1 {
+			m.ruleCursor++
+		}
+	case "a", "n":
+		prefill := "*:"
+		if session, ok := m.currentSession(); ok {
+			prefill = session.Name + ":"
+		}
+		m.state = StateAddRule
+		m.dialog = newDialog("Add alert rule", "[Enter] add  [Esc] cancel",
+			textField("Rule", "session:regexp", prefill))
+		m.dialog.body = []string{
+			"SESSION:REGEXP, e.g. api:panic:|FAIL",
+			DimStyle.Render("* matches every session"),
+		}
+	case "d", "x":
+		if m.ruleCursor < len(rules) {
+			m.matcher.RemoveRule(m.ruleCursor)
+			if m.ruleCursor > 0 && m.ruleCursor >= len(rules)-1 {
+				m.ruleCursor--
+			}
+		}
+	case "esc", "q":
+		m.state = StateList
+	}
+	return m, nil
+}
+
+func (m Model) handleAddRule(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		rule, err := watch.ParseRule(m.dialog.value(0))
+		if err != nil {
+			m.dialog.fail(err.Error())
+			return m, nil
+		}
+		m.matcher.AddRule(rule)
+		m.ruleCursor = len(m.matcher.Rules()) - 1
+		m.closeDialog()
+		return m, m.loadSessions()
+	}
+	return m, m.dialog.update(msg)
+}
+
+func (m Model) renderAlertRules() string {
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render("Alert rules"))
+	b.WriteString("\n\n")
+
+	rules := m.matcher.Rules()
+	if len(rules) == 0 {
+		b.WriteString(DimStyle.Render("No rules. Press 'a' to add one."))
+		b.WriteString("\n")
+	}
+	for i, r := range rules {
+		cursor := "  "
+		session := fmt.Sprintf("%-12s", r.Session)
+		if i == m.ruleCursor {
+			cursor = CursorStyle.Render("> ")
+			session = SelectedItemStyle.Render(session)
+		}
+		b.WriteString(fmt.Sprintf("%s%s  /%s/\n", cursor, session, r.Pattern))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("[a]dd  [d]elete  [esc]back"))
+	return b.String()
+}
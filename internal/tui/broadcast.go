@@ -0,0 +1,258 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// broadcastTarget is one recipient of a broadcast command.
+type broadcastTarget struct {
+	target string // byobu target passed to send-keys
+	label  string // Human-readable description
+}
+
+// broadcastResult is the outcome of sending to one target.
+type broadcastResult struct {
+	target broadcastTarget
+	err    error
+}
+
+// broadcastTargetsMsg contains the resolved targets for a filter.
+type broadcastTargetsMsg struct {
+	targets []broadcastTarget
+	err     error
+}
+
+// broadcastDoneMsg contains the per-target results of a broadcast.
+type broadcastDoneMsg struct {
+	results []broadcastResult
+}
+
+// resolveTargets turns a broadcast filter into targets:
+//   - ""          marked sessions
+//   - "cwd:PATH"  every pane whose working directory is PATH or below it
+//   - otherwise   sessions whose name matches the glob, or the exact name
+func resolveTargets(client byobu.Client, filter string, marked []string) tea.Cmd {
+	return func() tea.Msg {
+		filter = strings.TrimSpace(filter)
+
+		if filter == "" {
+			if len(marked) == 0 {
+				return broadcastTargetsMsg{err: fmt.Errorf("no sessions marked; press space to mark sessions or enter a filter")}
+			}
+			targets := make([]broadcastTarget, 0, len(marked))
+			for _, name := range marked {
+				targets = append(targets, broadcastTarget{target: name, label: "session " + name})
+			}
+			return broadcastTargetsMsg{targets: targets}
+		}
+
+		if dir, ok := strings.CutPrefix(filter, "cwd:"); ok {
+			return resolvePaneTargets(client, expandHome(dir))
+		}
+
+		sessions, err := client.ListSessions()
+		if err != nil {
+			return broadcastTargetsMsg{err: err}
+		}
+		var targets []broadcastTarget
+		for _, s := range sessions {
+			if matchSessionFilter(filter, s.Name) {
+				targets = append(targets, broadcastTarget{target: s.Name, label: "session " + s.Name})
+			}
+		}
+		if len(targets) == 0 {
+			return broadcastTargetsMsg{err: fmt.Errorf("no sessions match '%s'", filter)}
+		}
+		return broadcastTargetsMsg{targets: targets}
+	}
+}
+
+func resolvePaneTargets(client byobu.Client, dir string) tea.Msg {
+	dir = filepath.Clean(dir)
+	panes, err := client.ListPanes()
+	if err != nil {
+		return broadcastTargetsMsg{err: err}
+	}
+	var targets []broadcastTarget
+	for _, p := range panes {
+		if p.CurrentPath == dir || strings.HasPrefix(p.CurrentPath, dir+string(filepath.Separator)) {
+			targets = append(targets, broadcastTarget{
+				target: p.ID,
+				label:  fmt.Sprintf("pane %s:%d.%d  %s", p.SessionName, p.WindowIndex, p.Index, p.CurrentPath),
+			})
+		}
+	}
+	if len(targets) == 0 {
+		return broadcastTargetsMsg{err: fmt.Errorf("no panes under '%s'", dir)}
+	}
+	return broadcastTargetsMsg{targets: targets}
+}
+
+// matchSessionFilter matches name against a glob, or exactly when the
+// filter has no glob metacharacters.
+func matchSessionFilter(filter, name string) bool {
+	if strings.ContainsAny(filter, "*?[") {
+		ok, _ := path.Match(filter, name)
+		return ok
+	}
+	return name == filter
+}
+
+// expandHome replaces a leading "~" with the user's home directory.
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(p, "~"))
+		}
+	}
+	return p
+}
+
+func broadcast(client byobu.Client, targets []broadcastTarget, keys string) tea.Cmd {
+	return func() tea.Msg {
+		results := make([]broadcastResult, 0, len(targets))
+		for _, t := range targets {
+			err := client.SendKeys(t.target, keys, true)
+			results = append(results, broadcastResult{target: t, err: err})
+		}
+		return broadcastDoneMsg{results: results}
+	}
+}
+
+// markedSessions returns marked session names in list order.
+func (m Model) markedSessions() []string {
+	var names []string
+	for _, s := range m.sessions {
+		if m.marked[s.Name] {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// maxPreviewTargets caps the target preview in the broadcast dialog.
+const maxPreviewTargets = 8
+
+func (m Model) startBroadcast() (tea.Model, tea.Cmd) {
+	m.state = StateBroadcastFilter
+	m.broadcastTargets = nil
+	m.broadcastResults = nil
+	m.dialog = newDialog("Broadcast", "[Enter] preview targets  [Esc] cancel",
+		textField("Targets", "empty = marked sessions, glob, or cwd:PATH", ""))
+	if marked := m.markedSessions(); len(marked) > 0 {
+		m.dialog.body = []string{DimStyle.Render("Marked: " + strings.Join(marked, ", "))}
+	}
+	return m, nil
+}
+
+// targetPreview lists the broadcast targets, capped at maxPreviewTargets.
+func (m Model) targetPreview() []string {
+	var lines []string
+	for i, t := range m.broadcastTargets {
+		if i == maxPreviewTargets {
+			lines = append(lines, DimStyle.Render(fmt.Sprintf("… and %d more", len(m.broadcastTargets)-i)))
+			break
+		}
+		lines = append(lines, "  "+t.label)
+	}
+	return lines
+}
+
+func (m Model) handleBroadcastTargets(msg broadcastTargetsMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateBroadcastFilter {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.dialog.fail(msg.err.Error())
+		return m, nil
+	}
+	m.broadcastTargets = msg.targets
+	m.state = StateBroadcastCommand
+	m.dialog = newDialog(fmt.Sprintf("Broadcast to %d targets", len(m.broadcastTargets)),
+		"[Enter] continue  [Esc] cancel", textField("Command", "command", ""))
+	m.dialog.body = m.targetPreview()
+	return m, nil
+}
+
+func (m Model) handleBroadcastDone(msg broadcastDoneMsg) (tea.Model, tea.Cmd) {
+	m.broadcastResults = msg.results
+	m.state = StateBroadcastReport
+
+	failed := 0
+	var lines []string
+	for _, r := range m.broadcastResults {
+		if r.err != nil {
+			failed++
+			lines = append(lines, ErrorStyle.Render("✗ ")+r.target.label+"  "+DimStyle.Render(r.err.Error()))
+		} else {
+			lines = append(lines, AttachedStyle.Render("✓ ")+r.target.label)
+		}
+	}
+	m.dialog = newDialog(fmt.Sprintf("Broadcast: %d sent, %d failed", len(m.broadcastResults)-failed, failed),
+		"Press any key to continue")
+	m.dialog.body = lines
+	return m, m.loadSessions()
+}
+
+func (m Model) handleBroadcastFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		return m, m.dialog.submit(resolveTargets(m.client, m.dialog.value(0), m.markedSessions()))
+	}
+	return m, m.dialog.update(msg)
+}
+
+func (m Model) handleBroadcastCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		keys := m.dialog.fields[0].input.Value()
+		if keys == "" {
+			m.dialog.fail("nothing to send")
+			return m, nil
+		}
+		m.broadcastKeys = keys
+		m.state = StateConfirmBroadcast
+		m.dialog = newDialog(fmt.Sprintf("Broadcast to %d targets", len(m.broadcastTargets)),
+			"[y/Enter] send  [n/Esc] cancel")
+		m.dialog.body = append(m.targetPreview(), "",
+			PromptStyle.Render(fmt.Sprintf("Send '%s' to %d targets?", keys, len(m.broadcastTargets))))
+		return m, nil
+	}
+	return m, m.dialog.update(msg)
+}
+
+func (m Model) handleConfirmBroadcast(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		return m, m.dialog.submit(broadcast(m.client, m.broadcastTargets, m.broadcastKeys))
+	case "n", "N", "esc":
+		m.closeDialog()
+	}
+	return m, nil
+}
+
+func (m Model) handleBroadcastReport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Any key dismisses the report
+	m.closeDialog()
+	m.broadcastResults = nil
+	return m, nil
+}
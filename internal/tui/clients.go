@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"byoman/internal/proc"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// clientInfo is an attached client plus where it connects from.
+type clientInfo struct {
+	byobu.AttachedClient
+	remote string // SSH client address, "" for local or unknown
+}
+
+// clientsLoadedMsg contains the clients attached to one session.
+type clientsLoadedMsg struct {
+	session string
+	clients []clientInfo
+	err     error
+}
+
+func loadClients(client byobu.Client, session string) tea.Cmd {
+	return func() tea.Msg {
+		all, err := client.ListClients()
+		if err != nil {
+			return clientsLoadedMsg{session: session, err: err}
+		}
+		var clients []clientInfo
+		for _, c := range all {
+			if c.SessionName == session {
+				clients = append(clients, clientInfo{AttachedClient: c, remote: proc.SSHClientAddr(c.PID)})
+			}
+		}
+		return clientsLoadedMsg{session: session, clients: clients}
+	}
+}
+
+func (m Model) handleClientsLoaded(msg clientsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	if session, ok := m.currentSession(); !ok || session.Name != msg.session {
+		return m, nil // Stale result for a session we've left
+	}
+	// Keep the cursor on the same client across refreshes
+	selected := 0
+	if m.clientCursor < len(m.clients) {
+		tty := m.clients[m.clientCursor].TTY
+		for i, c := range msg.clients {
+			if c.TTY == tty {
+				selected = i
+				break
+			}
+		}
+	}
+	m.clients = msg.clients
+	m.clientCursor = selected
+	m.state = StateClients
+	return m, nil
+}
+
+func (m Model) handleClientsState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	session, _ := m.currentSession()
+
+	switch msg.String() {
+	case "up", "k":
+		if m.clientCursor > 0 {
+			m.clientCursor--
+		}
+	case "down", "j":
+		if m.clientCursor < len(m.clients)-1 {
+			m.clientCursor++
+		}
+	case "d":
+		if m.clientCursor < len(m.clients) {
+			tty := m.clients[m.clientCursor].TTY
+			return m, tea.Sequence(
+				runAction(func() error { return m.client.DetachClient(tty) }),
+				loadClients(m.client, session.Name),
+			)
+		}
+	case "D":
+		if m.clientCursor < len(m.clients) {
+			tty := m.clients[m.clientCursor].TTY
+			return m, tea.Sequence(
+				runAction(func() error { return m.client.DetachOtherClients(session.Name, tty) }),
+				loadClients(m.client, session.Name),
+			)
+		}
+	case "esc", "q":
+		m.state = StateList
+		m.clients = nil
+	}
+	return m, nil
+}
+
+func (m Model) renderClients() string {
+	session, _ := m.currentSession()
+
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render(fmt.Sprintf("Clients of '%s'", session.Name)))
+	b.WriteString("\n\n")
+
+	if len(m.clients) == 0 {
+		b.WriteString(DimStyle.Render("No clients attached."))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  %-12s %-9s %-16s %-14s %9s  %s", "TTY", "SIZE", "FROM", "TERM", "ATTACHED", "IDLE")))
+		b.WriteString("\n")
+	}
+	now := time.Now()
+	for i, c := range m.clients {
+		cursor := "  "
+		tty := fmt.Sprintf("%-12s", strings.TrimPrefix(c.TTY, "/dev/"))
+		if i == m.clientCursor {
+			cursor = CursorStyle.Render("> ")
+			tty = SelectedItemStyle.Render(tty)
+		}
+
+		from := c.User
+		if c.remote != "" {
+			from += "@" + c.remote
+		} else {
+			from += " (local)"
+		}
+		line := fmt.Sprintf("%s%s %-9s %-16s %-14s %9s  %s", cursor, tty,
+			fmt.Sprintf("%dx%d", c.Width, c.Height), from, c.TermName,
+			formatElapsed(now.Sub(c.Created)), formatElapsed(now.Sub(c.Activity)))
+		if c.ReadOnly {
+			line += "  " + DimStyle.Render("[read-only]")
+		}
+		if m.width > 0 {
+			line = ansi.Truncate(line, m.width, "…")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("[d]etach client  [D]etach all others  [esc]back"))
+	return b.String()
+}
@@ -0,0 +1,304 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// dialogField is a text input or a choice cycled with left/right.
+type dialogField struct {
+	label   string
+	input   textinput.Model
+	choices []string // Non-nil for choice fields
+	choice  int
+}
+
+// textField creates a text input field.
+func textField(label, placeholder, value string) dialogField {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.Prompt = ""
+	ti.SetValue(value)
+	return dialogField{label: label, input: ti}
+}
+
+// choiceField creates a field choosing one of choices, starting at selected.
+func choiceField(label string, choices []string, selected string) dialogField {
+	f := dialogField{label: label, choices: choices}
+	for i, c := range choices {
+		if c == selected {
+			f.choice = i
+		}
+	}
+	return f
+}
+
+// value returns the field's text or chosen option.
+func (f dialogField) value() string {
+	if f.choices != nil {
+		if len(f.choices) == 0 {
+			return ""
+		}
+		return f.choices[f.choice]
+	}
+	return strings.TrimSpace(f.input.Value())
+}
+
+// dialog is a modal box drawn over the current screen. Enter submits and
+// Esc cancels in every dialog; Tab and Shift+Tab move between fields.
+type dialog struct {
+	title   string
+	body    []string // Text shown above the fields
+	fields  []dialogField
+	focus   int
+	message string // Inline validation or action error
+	help    string
+	pending bool // Submitted, waiting for the action result
+	width   int  // Inner width wanted, 0 for the default
+}
+
+// newDialog creates a dialog and focuses its first field.
+func newDialog(title, help string, fields ...dialogField) *dialog {
+	d := &dialog{title: title, help: help, fields: fields}
+	d.setFocus(0)
+	return d
+}
+
+// value returns the value of field i.
+func (d *dialog) value(i int) string {
+	if i < 0 || i >= len(d.fields) {
+		return ""
+	}
+	return d.fields[i].value()
+}
+
+// submit marks the dialog as waiting for cmd. A sessionActionMsg from cmd
+// is tagged with the dialog, so only the result of its own action is
+// reported in it.
+func (d *dialog) submit(cmd tea.Cmd) tea.Cmd {
+	d.pending = true
+	return func() tea.Msg {
+		msg := cmd()
+		if result, ok := msg.(sessionActionMsg); ok {
+			result.dialog = d
+			return result
+		}
+		return msg
+	}
+}
+
+// fail shows an inline error and re-enables input.
+func (d *dialog) fail(message string) {
+	d.message = message
+	d.pending = false
+}
+
+func (d *dialog) setFocus(i int) {
+	if len(d.fields) == 0 {
+		return
+	}
+	i = (i + len(d.fields)) % len(d.fields)
+	for j := range d.fields {
+		if d.fields[j].choices != nil {
+			continue
+		}
+		if j == i {
+			d.fields[j].input.Focus()
+		} else {
+			d.fields[j].input.Blur()
+		}
+	}
+	d.focus = i
+}
+
+// update handles field navigation and editing. Enter and Esc are left to
+// the caller, which knows what submitting means.
+func (d *dialog) update(msg tea.KeyMsg) tea.Cmd {
+	if d.pending || len(d.fields) == 0 {
+		return nil
+	}
+	field := &d.fields[d.focus]
+
+	switch msg.String() {
+	case "tab", "down":
+		d.setFocus(d.focus + 1)
+		return nil
+	case "shift+tab", "up":
+		d.setFocus(d.focus - 1)
+		return nil
+	}
+
+	if field.choices != nil {
+		switch msg.String() {
+		case "left", "h":
+			field.choice = (field.choice - 1 + len(field.choices)) % len(field.choices)
+		case "right", "l", " ":
+			field.choice = (field.choice + 1) % len(field.choices)
+		}
+		return nil
+	}
+
+	d.message = ""
+	var cmd tea.Cmd
+	field.input, cmd = field.input.Update(msg)
+	return cmd
+}
+
+// view renders the dialog box for a screen of the given size. Body text
+// that doesn't fit is cut short.
+func (d *dialog) view(width, height int) string {
+	inner := 60
+	if d.width > 0 {
+		inner = d.width
+	}
+	inner = min(inner, width-6)
+	if inner < 20 {
+		inner = max(width-4, 10)
+	}
+
+	// Border, title, fields and help take the remaining lines
+	room := height - 6 - len(d.fields)
+	if len(d.fields) > 0 {
+		room--
+	}
+	if d.message != "" {
+		room -= 2
+	}
+	body := d.body
+	if len(body) > room {
+		body = append(body[:max(room-1, 0):max(room-1, 0)],
+			DimStyle.Render(fmt.Sprintf("… %d more", len(d.body)-max(room-1, 0))))
+	}
+
+	var lines []string
+	lines = append(lines, TitleStyle.UnsetMarginBottom().Render(d.title), "")
+	for _, line := range body {
+		lines = append(lines, ansi.Truncate(line, inner, "…"))
+	}
+	if len(body) > 0 && len(d.fields) > 0 {
+		lines = append(lines, "")
+	}
+
+	labelWidth := 0
+	for _, f := range d.fields {
+		labelWidth = max(labelWidth, lipgloss.Width(f.label))
+	}
+	for i, f := range d.fields {
+		label := f.label + strings.Repeat(" ", labelWidth-lipgloss.Width(f.label)) + "  "
+		if i == d.focus {
+			label = PromptStyle.Render(label)
+		} else {
+			label = DimStyle.Render(label)
+		}
+
+		var value string
+		if f.choices != nil {
+			value = "‹ " + f.value() + " ›"
+			if i == d.focus {
+				value = SelectedItemStyle.Render(value)
+			}
+		} else {
+			f.input.Width = inner - labelWidth - 3
+			value = f.input.View()
+		}
+		lines = append(lines, label+value)
+	}
+
+	if d.message != "" {
+		lines = append(lines, "", ErrorStyle.Render(ansi.Truncate(d.message, inner, "…")))
+	}
+	help := d.help
+	if d.pending {
+		help = "working…"
+	}
+	lines = append(lines, "", HelpStyle.UnsetMarginTop().Render(ansi.Truncate(help, inner, "…")))
+
+	return DialogStyle.Width(inner + 2).Render(strings.Join(lines, "\n"))
+}
+
+// overlay draws box centered on top of background, which is expected to
+// have one line per terminal row. A box larger than the screen is clipped
+// so the terminal never wraps its lines.
+func overlay(background, box string, width, height int) string {
+	bg := strings.Split(background, "\n")
+	for len(bg) < height {
+		bg = append(bg, "")
+	}
+	bg = bg[:height]
+	fg := strings.Split(box, "\n")
+
+	boxWidth := min(lipgloss.Width(box), width)
+	for i, line := range fg {
+		fg[i] = ansi.Truncate(line, boxWidth, "")
+	}
+	left := max((width-boxWidth)/2, 0)
+	top := max((height-len(fg))/2, 0)
+
+	for i, line := range fg {
+		y := top + i
+		if y >= len(bg) {
+			break
+		}
+		base := bg[y]
+		prefix := ansi.Truncate(base, left, "")
+		prefix += strings.Repeat(" ", left-ansi.StringWidth(prefix))
+		suffix := ansi.TruncateLeft(base, left+boxWidth, "")
+		bg[y] = prefix + "\x1b[0m" + line + "\x1b[0m" + suffix
+	}
+	return strings.Join(bg, "\n")
+}
+
+// backgroundState returns the screen a dialog state is drawn over.
+func (m Model) backgroundState() ViewState {
+	switch m.state {
+	case StateSendKeys:
+		return m.sendReturnState
+	case StateAddRule:
+		return StateAlertRules
+	case StateConfirmSignal:
+		return StateProcesses
+	case StateNewWindow, StateRenameWindow, StateKillWindow, StateMoveWindow, StateLinkWindow:
+		return StateWindows
+	case StateSplitPane, StateKillPane, StateJoinPane, StateSelectLayout:
+		return StatePanes
+	default:
+		return StateList
+	}
+}
+
+// closeDialog dismisses the dialog and returns to the screen beneath it.
+func (m *Model) closeDialog() {
+	m.state = m.backgroundState()
+	m.dialog = nil
+}
+
+// handleDialogResult finishes a submitted dialog: errors stay inline so the
+// input can be corrected, success closes it.
+func (m Model) handleDialogResult(msg sessionActionMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.dialog.fail(msg.err.Error())
+		return m, nil
+	}
+	m.closeDialog()
+	if msg.warning != "" {
+		m.notice = msg.warning
+	}
+	if msg.attach != "" {
+		m.selectedSession = msg.attach
+		m.attachOptions.Throwaway = msg.throwaway
+		m.quitting = true
+		return m, tea.Quit
+	}
+	switch m.state {
+	case StateProcesses:
+		return m, tea.Batch(m.loadSessions(), loadProcessTree(m.currentPane()))
+	case StatePanes:
+		return m, tea.Batch(m.loadSessions(), loadPanes(m.client, m.currentPane().SessionName))
+	}
+	return m, m.loadSessions()
+}
@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listKeyMap holds the key bindings of the session list. Each binding can
+// be changed in the config file as key.<action> = key, key...
+type listKeyMap struct {
+	Up              key.Binding
+	Down            key.Binding
+	Attach          key.Binding
+	AttachExclusive key.Binding
+	AttachReadOnly  key.Binding
+	Share           key.Binding
+	View            key.Binding
+	New             key.Binding
+	Rename          key.Binding
+	Kill            key.Binding
+	Windows         key.Binding
+	Panes           key.Binding
+	Clients         key.Binding
+	Exec            key.Binding
+	Mark            key.Binding
+	Broadcast       key.Binding
+	StatusBar       key.Binding
+	Options         key.Binding
+	Watch           key.Binding
+	Alerts          key.Binding
+	ClearAlerts     key.Binding
+	Log             key.Binding
+	Logs            key.Binding
+	Usage           key.Binding
+	Sort            key.Binding
+	Palette         key.Binding
+	Help            key.Binding
+	Quit            key.Binding
+}
+
+// binding creates a key binding whose help shows its keys.
+func binding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keysLabel(keys), desc))
+}
+
+// keysLabel renders keys for the help, e.g. "↑/k".
+func keysLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		switch k {
+		case " ":
+			labels[i] = "space"
+		case "up":
+			labels[i] = "↑"
+		case "down":
+			labels[i] = "↓"
+		default:
+			labels[i] = k
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+func defaultListKeys() listKeyMap {
+	return listKeyMap{
+		Up:              binding("up", "up", "k"),
+		Down:            binding("down", "down", "j"),
+		Attach:          binding("attach", "enter"),
+		AttachExclusive: binding("attach, detach others", "D"),
+		AttachReadOnly:  binding("attach read-only", "R"),
+		Share:           binding("share read-only", "S"),
+		View:            binding("open a view", "V"),
+		New:             binding("new", "n"),
+		Rename:          binding("rename", "r"),
+		Kill:            binding("kill", "K"),
+		Windows:         binding("windows", "W"),
+		Panes:           binding("panes", "p"),
+		Clients:         binding("clients", "C"),
+		Exec:            binding("exec", "x"),
+		Mark:            binding("mark", " "),
+		Broadcast:       binding("broadcast", "b"),
+		StatusBar:       binding("minimal bar", "m"),
+		Options:         binding("options", "o"),
+		Watch:           binding("watch", "w"),
+		Alerts:          binding("alerts", "a"),
+		ClearAlerts:     binding("clear alerts", "c"),
+		Log:             binding("toggle log", "L"),
+		Logs:            binding("logs", "l"),
+		Usage:           binding("usage", "u"),
+		Sort:            binding("sort", "s"),
+		Palette:         binding("commands", "ctrl+p", ":"),
+		Help:            binding("help", "?"),
+		Quit:            binding("quit", "q", "ctrl+c"),
+	}
+}
+
+// actions maps the action names used in the config file to bindings.
+func (k *listKeyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":               &k.Up,
+		"down":             &k.Down,
+		"attach":           &k.Attach,
+		"attach-exclusive": &k.AttachExclusive,
+		"attach-read-only": &k.AttachReadOnly,
+		"share":            &k.Share,
+		"view":             &k.View,
+		"new":              &k.New,
+		"rename":           &k.Rename,
+		"kill":             &k.Kill,
+		"windows":          &k.Windows,
+		"panes":            &k.Panes,
+		"clients":          &k.Clients,
+		"exec":             &k.Exec,
+		"mark":             &k.Mark,
+		"broadcast":        &k.Broadcast,
+		"status-bar":       &k.StatusBar,
+		"options":          &k.Options,
+		"watch":            &k.Watch,
+		"alerts":           &k.Alerts,
+		"clear-alerts":     &k.ClearAlerts,
+		"log":              &k.Log,
+		"logs":             &k.Logs,
+		"usage":            &k.Usage,
+		"sort":             &k.Sort,
+		"palette":          &k.Palette,
+		"help":             &k.Help,
+		"quit":             &k.Quit,
+	}
+}
+
+// newListKeys returns the default bindings with overrides applied. A key
+// may only be bound to one action.
+func newListKeys(overrides map[string][]string) (listKeyMap, error) {
+	keys := defaultListKeys()
+	actions := keys.actions()
+	for name, ks := range overrides {
+		b, ok := actions[name]
+		if !ok {
+			return listKeyMap{}, fmt.Errorf("key.%s: unknown action (available: %s)", name, strings.Join(sortedNames(actions), ", "))
+		}
+		ks = append([]string(nil), ks...)
+		for i, k := range ks {
+			switch k {
+			case "space":
+				ks[i] = " "
+			case "comma":
+				ks[i] = ","
+			}
+		}
+		*b = binding(b.Help().Desc, ks...)
+	}
+
+	owner := make(map[string]string)
+	for _, name := range sortedNames(actions) {
+		for _, k := range actions[name].Keys() {
+			if other, ok := owner[k]; ok {
+				return listKeyMap{}, fmt.Errorf("key '%s' is bound to both %s and %s", keysLabel([]string{k}), other, name)
+			}
+			owner[k] = name
+		}
+	}
+	return keys, nil
+}
+
+// actionFor returns the action bound to the key of msg.
+func (k listKeyMap) actionFor(msg tea.KeyMsg) (string, bool) {
+	for name, b := range k.actions() {
+		if key.Matches(msg, *b) {
+			return name, true // Keys are bound to one action only
+		}
+	}
+	return "", false
+}
+
+func sortedNames(actions map[string]*key.Binding) []string {
+	names := make([]string, 0, len(actions))
+	for name := range actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Actions shown in the help bar, and on narrow screens.
+var (
+	shortHelpActions   = []string{"attach", "new", "rename", "kill", "windows", "panes", "exec", "broadcast", "palette", "help", "quit"}
+	compactHelpActions = []string{"new", "rename", "kill", "exec", "help", "quit"}
+)
+
+// FullHelp returns every binding, in columns, for the ? overlay.
+func (k listKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Attach, k.AttachExclusive, k.AttachReadOnly, k.View, k.Share},
+		{k.New, k.Rename, k.Kill, k.Mark, k.Exec, k.Broadcast, k.StatusBar, k.Options},
+		{k.Windows, k.Panes, k.Clients, k.Log, k.Logs, k.Usage, k.Sort},
+		{k.Watch, k.Alerts, k.ClearAlerts, k.Palette, k.Help, k.Quit},
+	}
+}
+
+// helpModel returns the help component styled for the current screen.
+func (m Model) helpModel() help.Model {
+	h := help.New()
+	h.Width = m.layout().width
+	h.ShortSeparator = m.helpSeparator()
+	h.Styles.ShortKey = FooterStyle.Bold(true)
+	h.Styles.ShortDesc = FooterStyle
+	h.Styles.ShortSeparator = FooterStyle
+	h.Styles.Ellipsis = FooterStyle
+	h.Styles.FullKey = PromptStyle
+	h.Styles.FullDesc = DimStyle
+	h.Styles.FullSeparator = DimStyle
+	return h
+}
+
+// helpActions returns the actions shown in the help bar.
+func (m Model) helpActions() []string {
+	if m.compact() {
+		return compactHelpActions
+	}
+	return shortHelpActions
+}
+
+// helpBindings returns the bindings of helpActions.
+func (m Model) helpBindings() []key.Binding {
+	actions := m.keys.actions()
+	bindings := make([]key.Binding, len(m.helpActions()))
+	for i, name := range m.helpActions() {
+		bindings[i] = *actions[name]
+	}
+	return bindings
+}
+
+// renderFullHelp renders the ? overlay listing every binding.
+func (m Model) renderFullHelp() string {
+	h := m.helpModel()
+	h.Width = max(m.layout().width-4, 0)
+	lines := []string{
+		TitleStyle.UnsetMarginBottom().Render("Keys"), "",
+		h.FullHelpView(m.keys.FullHelp()), "",
+		HelpStyle.UnsetMarginTop().Render("Press any key to close"),
+	}
+	return DialogStyle.Render(strings.Join(lines, "\n"))
+}
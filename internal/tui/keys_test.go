@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNewListKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+		wantErr   string
+		check     func(t *testing.T, k listKeyMap)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, k listKeyMap) {
+				if !slices.Equal(k.Kill.Keys(), []string{"K"}) {
+					t.Errorf("kill keys = %q", k.Kill.Keys())
+				}
+			},
+		},
+		{
+			name:      "rebind",
+			overrides: map[string][]string{"kill": {"ctrl+k"}, "exec": {"e", "x"}},
+			check: func(t *testing.T, k listKeyMap) {
+				if !slices.Equal(k.Kill.Keys(), []string{"ctrl+k"}) || k.Kill.Help().Key != "ctrl+k" {
+					t.Errorf("kill keys = %q, help %q", k.Kill.Keys(), k.Kill.Help().Key)
+				}
+				if k.Kill.Help().Desc != "kill" {
+					t.Errorf("kill lost its description: %q", k.Kill.Help().Desc)
+				}
+			},
+		},
+		{
+			name:      "key names",
+			overrides: map[string][]string{"mark": {"m"}, "status-bar": {"space", "comma"}},
+			check: func(t *testing.T, k listKeyMap) {
+				if !slices.Equal(k.StatusBar.Keys(), []string{" ", ","}) {
+					t.Errorf("status-bar keys = %q", k.StatusBar.Keys())
+				}
+			},
+		},
+		{
+			name:      "disable",
+			overrides: map[string][]string{"watch": nil},
+			check: func(t *testing.T, k listKeyMap) {
+				if k.Watch.Enabled() {
+					t.Error("watch still enabled")
+				}
+			},
+		},
+		{
+			name:      "swap keys between actions",
+			overrides: map[string][]string{"kill": {"r"}, "rename": {"K"}},
+		},
+		{
+			name:      "duplicate with a default",
+			overrides: map[string][]string{"kill": {"n"}},
+			wantErr:   "key 'n' is bound to both kill and new",
+		},
+		{
+			name:      "duplicate between overrides",
+			overrides: map[string][]string{"kill": {"z"}, "exec": {"z"}},
+			wantErr:   "key 'z' is bound to both exec and kill",
+		},
+		{
+			name:      "duplicate space",
+			overrides: map[string][]string{"exec": {"space"}},
+			wantErr:   "key 'space' is bound to both exec and mark",
+		},
+		{
+			name:      "unknown action",
+			overrides: map[string][]string{"explode": {"e"}},
+			wantErr:   "key.explode: unknown action",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := newListKeys(tt.overrides)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("newListKeys() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.check != nil {
+				tt.check(t, k)
+			}
+		})
+	}
+}
+
+func TestActionFor(t *testing.T) {
+	k, err := newListKeys(map[string][]string{"kill": {"ctrl+k"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		msg    tea.KeyMsg
+		action string
+	}{
+		{tea.KeyMsg{Type: tea.KeyCtrlK}, "kill"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")}, ""},
+		{tea.KeyMsg{Type: tea.KeyEnter}, "attach"},
+		{tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, "mark"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}, "down"},
+		{tea.KeyMsg{Type: tea.KeyPgDown}, ""},
+	}
+	for _, tt := range tests {
+		action, ok := k.actionFor(tt.msg)
+		if action != tt.action || ok != (tt.action != "") {
+			t.Errorf("actionFor(%q) = %q, %v, want %q", tt.msg, action, ok, tt.action)
+		}
+	}
+}
+
+func TestHelpActionsAreBound(t *testing.T) {
+	k := defaultListKeys()
+	actions := k.actions()
+	for _, name := range append(slices.Clone(shortHelpActions), compactHelpActions...) {
+		if _, ok := actions[name]; !ok {
+			t.Errorf("help bar shows unknown action %q", name)
+		}
+	}
+}
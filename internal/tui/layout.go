@@ -0,0 +1,306 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// compactWidth is the terminal width below which sessions are shown as
+// two-line cards, e.g. on a phone over SSH.
+const compactWidth = 60
+
+// cardHeight is the number of lines per card, including the spacer line
+// that makes each card a larger tap target.
+const cardHeight = 3
+
+// Fallback terminal size until the first tea.WindowSizeMsg arrives.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// detailHeight is the height of the detail panel (rule + content lines).
+const detailHeight = 5
+
+// screenLayout describes the fixed regions of the main screen, top to
+// bottom: header, session list, detail panel, help bar and status line.
+// Region sizes only depend on the terminal size, so moving the cursor,
+// refreshing or showing an error never shifts content.
+type screenLayout struct {
+	width      int
+	height     int
+	listTop    int  // First line of the session list
+	listHeight int  // Lines available to the session list
+	rowHeight  int  // Lines per session (1, or cardHeight when compact)
+	detailTop  int  // First line of the detail panel
+	detailRows int  // Lines of the detail panel (0 = hidden)
+	helpY      int  // Line of the help bar, -1 when hidden
+	statusY    int  // Line of the status line (prompts, errors, notices)
+	minimal    bool // Too small for the list: only header and status line
+}
+
+// compact reports whether the narrow card layout is in use.
+func (m Model) compact() bool {
+	return m.width > 0 && m.width < compactWidth
+}
+
+// layout computes the screen regions for the current terminal size.
+func (m Model) layout() screenLayout {
+	l := screenLayout{width: m.width, height: m.height, listTop: 2, rowHeight: 1}
+	if l.width <= 0 {
+		l.width = defaultWidth
+	}
+	if l.height <= 0 {
+		l.height = defaultHeight
+	}
+	if m.compact() {
+		l.rowHeight = cardHeight
+	} else if l.height >= 16 {
+		l.detailRows = detailHeight
+	}
+
+	l.statusY = l.height - 1
+	// One row of the list needs a blank line above the help bar, the help
+	// bar and the status line below it
+	if l.height < l.listTop+l.rowHeight+3 {
+		l.minimal = true
+		l.helpY = -1
+		l.detailTop = l.statusY
+		return l
+	}
+	l.helpY = l.height - 2
+	l.detailTop = l.helpY - 1 - l.detailRows // Blank line above the help bar
+	l.listHeight = l.detailTop - l.listTop
+	return l
+}
+
+// visibleRows returns how many sessions fit in the list region.
+func (l screenLayout) visibleRows() int {
+	return l.listHeight / l.rowHeight
+}
+
+// syncScroll adjusts the list offset so the cursor stays visible.
+func (m *Model) syncScroll() {
+	visible := max(m.layout().visibleRows(), 1)
+	cursor := m.list.Index()
+	if cursor < m.listOffset {
+		m.listOffset = cursor
+	}
+	if cursor >= m.listOffset+visible {
+		m.listOffset = cursor - visible + 1
+	}
+	if max := len(m.sessions) - visible; m.listOffset > max {
+		m.listOffset = max
+	}
+	if m.listOffset < 0 {
+		m.listOffset = 0
+	}
+}
+
+// renderMain renders the session list screen into its fixed regions.
+func (m Model) renderMain() string {
+	l := m.layout()
+	lines := make([]string, l.height)
+
+	lines[0] = m.renderHeader()
+	if l.minimal {
+		// On a single line an error or notice replaces the header
+		if status := m.renderStatusLine(); status != "" || l.statusY > 0 {
+			lines[l.statusY] = status
+		}
+		return fitLines(lines, l.width)
+	}
+
+	if len(m.sessions) == 0 {
+		lines[l.listTop] = DimStyle.Render("No byobu sessions. Press 'n' to create one.")
+	}
+	y := l.listTop
+	for i := m.listOffset; i < len(m.sessions) && y+l.rowHeight <= l.listTop+l.listHeight; i++ {
+		for _, line := range m.renderSession(i) {
+			lines[y] = line
+			y++
+		}
+	}
+
+	if l.detailRows > 0 {
+		copy(lines[l.detailTop:l.detailTop+l.detailRows], m.renderDetail(l.detailRows))
+	}
+
+	lines[l.helpY] = m.renderHelp()
+	lines[l.statusY] = m.renderStatusLine()
+	return fitLines(lines, l.width)
+}
+
+// fitLines truncates lines to width and joins them.
+func fitLines(lines []string, width int) string {
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderHeader renders the title, sort mode and position in the list.
+func (m Model) renderHeader() string {
+	title := "byobu sessions"
+	if m.sortMode != SortDefault {
+		title += fmt.Sprintf(" (by %s)", m.sortMode)
+	}
+	header := HeaderStyle.Render(title)
+	if n := len(m.sessions); n > 0 {
+		header += "  " + DimStyle.Render(fmt.Sprintf("%d/%d", m.list.Index()+1, n))
+	}
+	return header
+}
+
+// renderStatusLine renders the latest error or notice.
+func (m Model) renderStatusLine() string {
+	switch {
+	case m.err != nil:
+		return ErrorStyle.Render(fmt.Sprintf("Error: %s", m.err.Error()))
+	case m.notice != "":
+		return NoticeStyle.Render(m.notice)
+	default:
+		return ""
+	}
+}
+
+// renderDetail renders the selected session's details, padded to rows.
+func (m Model) renderDetail(rows int) []string {
+	lines := make([]string, rows)
+	lines[0] = DimStyle.Render(strings.Repeat("─", m.layout().width))
+
+	session, ok := m.currentSession()
+	if !ok {
+		return lines
+	}
+
+	info := []string{
+		"created " + formatAgo(session.Created),
+		"last attached " + formatAgo(session.LastAttached),
+	}
+	switch {
+	case session.Attached == 1:
+		info = append(info, "1 client")
+	case session.Attached > 1:
+		info = append(info, fmt.Sprintf("%d clients", session.Attached))
+	}
+	if m.showUsage {
+		u := m.usage.sessions[session.Name]
+		info = append(info, fmt.Sprintf("%s cpu %s mem", strings.TrimSpace(formatCPU(u.CPU)), formatBytes(u.RSS)))
+	}
+	detail := []string{
+		SelectedItemStyle.Render(session.Name) + "  " + DimStyle.Render(strings.Join(info, " · ")),
+		DimStyle.Render("windows  ") + renderWindows(session),
+		DimStyle.Render("commands ") + strings.Join(session.Commands, ", "),
+	}
+	if session.Grouped {
+		detail = append(detail, DimStyle.Render("group    ")+strings.Join(m.groupMembers(session.Group), ", "))
+	}
+	copy(lines[1:], detail)
+	return lines
+}
+
+// formatAgo renders a timestamp as a relative age, e.g. "5m02s ago".
+func formatAgo(t time.Time) string {
+	if t.IsZero() || t.Unix() == 0 {
+		return "never"
+	}
+	return formatElapsed(time.Since(t)) + " ago"
+}
+
+// sessionAt returns the index of the session rendered at screen line y.
+func (m Model) sessionAt(y int) (int, bool) {
+	l := m.layout()
+	if y < l.listTop || y >= l.listTop+l.listHeight {
+		return 0, false
+	}
+	i := m.listOffset + (y-l.listTop)/l.rowHeight
+	if i >= len(m.sessions) {
+		return 0, false
+	}
+	return i, true
+}
+
+// doubleClickInterval is the longest gap between clicks of a double-click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// handleMouse drives the session list with the mouse: click selects,
+// double-click attaches, the wheel moves the selection and clicking a help
+// bar action runs it. In the compact layout a second tap on the selected
+// card also attaches, since double-taps are unreliable on touch screens.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateList {
+		return m, nil
+	}
+	if m.showHelp {
+		if msg.Action == tea.MouseActionRelease {
+			m.showHelp = false
+		}
+		return m, nil
+	}
+
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp && msg.Action == tea.MouseActionPress:
+		m.list.CursorUp()
+		return m, nil
+	case msg.Button == tea.MouseButtonWheelDown && msg.Action == tea.MouseActionPress:
+		m.list.CursorDown()
+		return m, nil
+	case msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionRelease:
+		return m, nil
+	}
+
+	if action, ok := m.helpActionAt(msg.X, msg.Y); ok {
+		return m.runListAction(action)
+	}
+
+	i, ok := m.sessionAt(msg.Y)
+	if !ok {
+		return m, nil
+	}
+
+	now := time.Now()
+	double := i == m.lastClickIndex && now.Sub(m.lastClick) <= doubleClickInterval
+	tapAgain := m.compact() && i == m.list.Index()
+	m.lastClick, m.lastClickIndex = now, i
+
+	if double || tapAgain {
+		m.selectedSession = m.sessions[i].Name
+		m.quitting = true
+		return m, tea.Quit
+	}
+	m.list.Select(i)
+	return m, nil
+}
+
+// helpActionAt returns the help bar action rendered at (x, y), laid
+// out as the help component renders it: "key desc" items between
+// separators, cut off at the screen width.
+func (m Model) helpActionAt(x, y int) (string, bool) {
+	if y != m.layout().helpY {
+		return "", false
+	}
+	sep := lipgloss.Width(m.helpSeparator())
+	start := 0
+	bindings := m.helpBindings()
+	for i, action := range m.helpActions() {
+		b := bindings[i]
+		if !b.Enabled() {
+			continue
+		}
+		end := start + lipgloss.Width(b.Help().Key) + 1 + lipgloss.Width(b.Help().Desc)
+		if end > m.layout().width {
+			break
+		}
+		if x >= start && x < end {
+			return action, true
+		}
+		start = end + sep
+	}
+	return "", false
+}
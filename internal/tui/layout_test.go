@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"byoman/internal/config"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// testModel returns a model sized width x height showing n sessions.
+func testModel(t *testing.T, width, height, n int) Model {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Theme = "dark" // Don't query the terminal
+	m, err := NewModel(nil, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sessions []byobu.Session
+	for i := range n {
+		sessions = append(sessions, byobu.Session{
+			Name:    strings.Repeat("s", i+1),
+			Windows: []byobu.Window{{Index: 0, Name: "bash", PaneCount: 1}},
+			Created: time.Unix(1700000000, 0),
+		})
+	}
+	m.updateSessionsPreserveSelection(sessions)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return updated.(Model)
+}
+
+func TestLayoutFitsScreen(t *testing.T) {
+	for _, width := range []int{1, 20, 40, 80} {
+		for height := 1; height <= 20; height++ {
+			for _, n := range []int{0, 3, 30} {
+				l := testModel(t, width, height, n).layout()
+				if l.minimal {
+					if l.listHeight != 0 || l.detailRows != 0 || l.helpY != -1 {
+						t.Errorf("%dx%d: minimal layout has regions %+v", width, height, l)
+					}
+					continue
+				}
+				if l.listHeight < l.rowHeight {
+					t.Errorf("%dx%d: list of %d lines can't hold a row of %d", width, height, l.listHeight, l.rowHeight)
+				}
+				if l.detailTop+l.detailRows >= l.helpY || l.helpY >= l.statusY || l.statusY != height-1 {
+					t.Errorf("%dx%d: regions overlap or overflow: %+v", width, height, l)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderMainTinySizes(t *testing.T) {
+	for _, width := range []int{1, 20, 40, 80} {
+		for height := 1; height <= 20; height++ {
+			for _, n := range []int{0, 3, 30} {
+				m := testModel(t, width, height, n)
+				m.err = errors.New("something went wrong")
+				help := m
+				help.showHelp = true
+				palette, _ := m.openPalette()
+				frames := []struct {
+					name string
+					m    Model
+				}{
+					{"list", m},
+					{"help", help},
+					{"dialog", palette.(Model)},
+				}
+				for _, f := range frames {
+					lines := strings.Split(f.m.View(), "\n")
+					if len(lines) != height {
+						t.Errorf("%dx%d, %d sessions, %s: got %d lines", width, height, n, f.name, len(lines))
+					}
+					for i, line := range lines {
+						if w := lipgloss.Width(line); w > width {
+							t.Errorf("%dx%d, %d sessions, %s: line %d is %d wide", width, height, n, f.name, i, w)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRenderMainMinimal(t *testing.T) {
+	m := testModel(t, 80, 2, 3)
+	lines := strings.Split(m.View(), "\n")
+	if !strings.Contains(lines[0], "byobu sessions") {
+		t.Errorf("header missing: %q", lines[0])
+	}
+
+	m = testModel(t, 80, 1, 3)
+	m.err = errors.New("boom")
+	if view := m.View(); !strings.Contains(view, "boom") {
+		t.Errorf("error not shown on a single line: %q", view)
+	}
+}
@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"byoman/internal/logs"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxLogView is how much of the end of a log file the viewer loads.
+const maxLogView = 1 << 20 // 1 MiB
+
+// logFilesMsg contains the files in the log directory.
+type logFilesMsg struct {
+	files []logs.File
+	err   error
+}
+
+// logContentMsg contains the contents of one log file.
+type logContentMsg struct {
+	file    logs.File
+	content string
+	err     error
+}
+
+// toggleLogging starts logging every pane matching filter, or stops it if
+// all of them are already logging.
+func toggleLogging(client byobu.Client, filter func(byobu.Pane) bool) tea.Cmd {
+	return func() tea.Msg {
+		all, err := client.ListPanes()
+		if err != nil {
+			return sessionActionMsg{err: err, logging: true}
+		}
+		var panes []byobu.Pane
+		start := false
+		for _, p := range all {
+			if filter(p) {
+				panes = append(panes, p)
+				start = start || !p.Piped
+			}
+		}
+
+		if !start {
+			for _, p := range panes {
+				if err := client.PipePane(p.ID, ""); err != nil {
+					return sessionActionMsg{err: err, logging: true}
+				}
+			}
+			return sessionActionMsg{logging: true}
+		}
+
+		dir, err := logs.Dir()
+		if err != nil {
+			return sessionActionMsg{err: err, logging: true}
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			return sessionActionMsg{err: fmt.Errorf("locate byoman binary: %w", err), logging: true}
+		}
+		now := time.Now()
+		for _, p := range panes {
+			if p.Piped {
+				continue
+			}
+			command := logs.PipeCommand(exe, logs.PathFor(dir, p, now), logs.DefaultMaxSize)
+			if err := client.PipePane(p.ID, command); err != nil {
+				return sessionActionMsg{err: err, logging: true}
+			}
+		}
+		return sessionActionMsg{logging: true}
+	}
+}
+
+func loadLogFiles() tea.Cmd {
+	return func() tea.Msg {
+		dir, err := logs.Dir()
+		if err != nil {
+			return logFilesMsg{err: err}
+		}
+		files, err := logs.List(dir)
+		return logFilesMsg{files: files, err: err}
+	}
+}
+
+func loadLogContent(file logs.File) tea.Cmd {
+	return func() tea.Msg {
+		f, err := os.Open(file.Path)
+		if err != nil {
+			return logContentMsg{file: file, err: err}
+		}
+		defer f.Close()
+
+		// Only load the tail of large logs
+		if file.Size > maxLogView {
+			if _, err := f.Seek(-maxLogView, io.SeekEnd); err != nil {
+				return logContentMsg{file: file, err: err}
+			}
+		}
+		data, err := io.ReadAll(f)
+		return logContentMsg{file: file, content: string(data), err: err}
+	}
+}
+
+func (m Model) handleLogFiles(msg logFilesMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.logFiles = msg.files
+	if m.logCursor >= len(m.logFiles) {
+		m.logCursor = 0
+	}
+	m.state = StateLogs
+	return m, nil
+}
+
+func (m Model) handleLogContent(msg logContentMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	width, height := m.width, m.height-4
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 0 {
+		height = 20
+	}
+	m.logViewport = viewport.New(width, height)
+	m.logViewport.SetContent(msg.content)
+	m.logViewport.GotoBottom()
+	m.logFile = msg.file
+	m.state = StateLogView
+	return m, nil
+}
+
+func (m Model) handleLogsState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.logCursor > 0 {
+			m.logCursor--
+		}
+	case "down", "j":
+		if m.logCursor < len(m.logFiles)-1 {
+			m.logCursor++
+		}
+	case "enter":
+		if m.logCursor < len(m.logFiles) {
+			return m, loadLogContent(m.logFiles[m.logCursor])
+		}
+	case "esc", "q":
+		m.state = StateList
+		m.logFiles = nil
+	}
+	return m, nil
+}
+
+func (m Model) handleLogViewState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.state = StateLogs
+		return m, nil
+	case "g", "home":
+		m.logViewport.GotoTop()
+		return m, nil
+	case "G", "end":
+		m.logViewport.GotoBottom()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.logViewport, cmd = m.logViewport.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderLogs() string {
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render("Logs"))
+	b.WriteString("\n\n")
+
+	if len(m.logFiles) == 0 {
+		b.WriteString(DimStyle.Render("No logs yet. Press 'L' on a session to start logging."))
+		b.WriteString("\n")
+	}
+	for i, f := range m.logFiles {
+		cursor := "  "
+		name := f.Name()
+		if i == m.logCursor {
+			cursor = CursorStyle.Render("> ")
+			name = SelectedItemStyle.Render(name)
+		}
+		meta := DimStyle.Render(fmt.Sprintf("%7s  %s", formatBytes(uint64(f.Size)), f.ModTime.Format("2006-01-02 15:04")))
+		b.WriteString(fmt.Sprintf("%s%s  %s\n", cursor, name, meta))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("[enter]view  [esc]back"))
+	return b.String()
+}
+
+func (m Model) renderLogView() string {
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render(m.logFile.Name()))
+	b.WriteString("\n")
+	b.WriteString(m.logViewport.View())
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render(fmt.Sprintf("%3.f%%  [↑/↓/pgup/pgdn]scroll  [g/G]top/bottom  [esc]back", m.logViewport.ScrollPercent()*100)))
+	return b.String()
+}
@@ -2,10 +2,16 @@ package tui
 
 import (
 	"byoman/internal/byobu"
+	"byoman/internal/config"
+	"byoman/internal/logs"
+	"byoman/internal/proc"
+	"byoman/internal/watch"
+	"sort"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
-	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -17,30 +23,173 @@ const (
 	StateConfirmKill
 	StateNewSession
 	StateRenameSession
+	StatePanes            // Pane picker for the selected session
+	StateProcesses        // Process tree of the selected pane
+	StateConfirmSignal    // Confirm sending a signal to a process
+	StateSendKeys         // Prompt for a command to send to a target
+	StateBroadcastFilter  // Prompt for broadcast targets
+	StateBroadcastCommand // Preview targets and prompt for the command
+	StateConfirmBroadcast // Confirm sending to all targets
+	StateBroadcastReport  // Per-target results of a broadcast
+	StateAlertRules       // List of output alert rules
+	StateAddRule          // Prompt for a new alert rule
+	StateLogs             // List of pane log files
+	StateLogView          // Contents of one log file
+	StateOptions          // Option overrides and profiles for a session
+	StateWindows          // Window list of the selected session
+	StateNewWindow        // Prompt for a new window
+	StateRenameWindow     // Prompt for a window's new name
+	StateKillWindow       // Confirm killing a window
+	StateMoveWindow       // Choose a session to move a window to
+	StateLinkWindow       // Choose a session to link a window into
+	StateSplitPane        // Prompt for splitting a pane
+	StateKillPane         // Confirm killing a pane
+	StateJoinPane         // Choose a window to join a pane into
+	StateSelectLayout     // Choose a layout preset for a pane's window
+	StateClients          // Clients attached to the selected session
+	StateShare            // Instructions for sharing a session read-only
+	StatePalette          // Fuzzy-searchable list of every command
 )
 
-// refreshInterval is the auto-refresh period.
-const refreshInterval = 3 * time.Second
+// SortMode controls the order of the session list.
+type SortMode int
+
+const (
+	SortDefault  SortMode = iota // By name, grouped sessions together
+	SortCPU                      // Highest CPU usage first
+	SortMemory                   // Highest resident memory first
+	SortActivity                 // Most recent activity first
+)
+
+// String returns the label shown in the list title.
+func (s SortMode) String() string {
+	switch s {
+	case SortCPU:
+		return "cpu"
+	case SortMemory:
+		return "memory"
+	case SortActivity:
+		return "activity"
+	default:
+		return "name"
+	}
+}
+
+// parseSortMode returns the sort mode named name, as shown by String.
+func parseSortMode(name string) SortMode {
+	for s := SortDefault; s <= SortActivity; s++ {
+		if s.String() == name {
+			return s
+		}
+	}
+	return SortDefault
+}
+
+// needsUsage reports whether the sort mode requires resource usage data.
+func (s SortMode) needsUsage() bool {
+	return s == SortCPU || s == SortMemory
+}
 
 // Model is the main bubbletea model.
 type Model struct {
 	// Data
 	sessions []byobu.Session
 	client   byobu.Client
+	config   config.Config // User preferences
+
+	// Resource usage (collected only while shown or sorted on)
+	collector *proc.Collector
+	usage     resourceUsage
+	showUsage bool
+
+	// Attention watcher (bells, silence, exits in detached sessions)
+	tracker   *watch.Tracker
+	notifiers []watch.Notifier
+	watching  bool
+	notice    string // Latest event, shown under the list
+
+	// Output alert rules
+	matcher    *watch.Matcher
+	alerts     map[string]int // Session name -> unseen rule matches
+	ruleCursor int
 
 	// UI State
+	keys         listKeyMap
+	showHelp     bool // Full help overlay
 	list         list.Model
 	state        ViewState
 	selectedName string // Preserved during refresh
+	listOffset   int    // First session visible in the list viewport
+	sortMode     SortMode
+	marked       map[string]bool // Marked session names for bulk actions
 
 	// Confirmation state
 	confirmTarget string
 
-	// Input state (for new/rename)
-	textInput textinput.Model
+	// Modal dialog for input and confirmation states (nil when none)
+	dialog        *dialog
+	suggestedName string // Name pre-filled in the new session dialog
+
+	// Send-keys state
+	sendTarget      string    // byobu target (session, window or pane)
+	sendTargetLabel string    // Human-readable target for the prompt
+	sendReturnState ViewState // State to return to after sending
+
+	// Broadcast state
+	broadcastTargets []broadcastTarget
+	broadcastKeys    string
+	broadcastResults []broadcastResult
+
+	// Option profiles
+	profiles       []byobu.Profile
+	defaultProfile string // Applied to sessions created by byoman
+	optionsSession string
+	sessionOptions []byobu.Option
+	profileCursor  int
+
+	// Log browser state
+	logging      map[string]bool // Session name -> any pane is being logged
+	checkLogging bool            // Check logging on the next refresh
+	logFiles     []logs.File
+	logCursor    int
+	logFile      logs.File
+	logViewport  viewport.Model
+
+	// Window list state
+	windowCursor int
+
+	// Attached client list state
+	clients      []clientInfo
+	clientCursor int
+
+	// Command palette state
+	palette       paletteCommands
+	paletteCursor int // Index into the current matches
+
+	// Share dialog state
+	shareInfo  byobu.ShareInfo
+	shareLines []string // Instructions to print on exit
+
+	// Pane/process inspector state
+	panes        []byobu.Pane
+	paneCursor   int
+	joinTargets  []byobu.Window // Windows offered by the join dialog
+	procNodes    []proc.Node
+	procCursor   int
+	signalTarget proc.Node
+	signal       syscall.Signal
+
+	// Mouse state for double-click detection
+	lastClick      time.Time
+	lastClickIndex int
+
+	// Terminal size
+	width  int
+	height int
 
 	// Output
 	selectedSession string // Populated on Enter, triggers attach
+	attachOptions   byobu.AttachOptions
 	quitting        bool
 	err             error
 	errExpiry       time.Time // When to clear the error
@@ -55,8 +204,19 @@ func (i sessionItem) Title() string       { return i.session.Name }
 func (i sessionItem) Description() string { return "" }
 func (i sessionItem) FilterValue() string { return i.session.Name }
 
-// NewModel creates a new TUI model.
-func NewModel(client byobu.Client) Model {
+// NewModel creates a new TUI model with the user's preferences and applies
+// their theme. It fails if the configured key bindings or theme are invalid.
+func NewModel(client byobu.Client, cfg config.Config) (Model, error) {
+	keys, err := newListKeys(cfg.Keys)
+	if err != nil {
+		return Model{}, err
+	}
+	theme, err := resolveTheme(cfg.Theme, cfg.Themes)
+	if err != nil {
+		return Model{}, err
+	}
+	applyTheme(theme)
+
 	// Create list with custom delegate
 	delegate := list.NewDefaultDelegate()
 	delegate.ShowDescription = false
@@ -67,23 +227,33 @@ func NewModel(client byobu.Client) Model {
 	l.SetFilteringEnabled(false)
 	l.SetShowHelp(false)
 	l.Styles.Title = TitleStyle
-
-	// Create text input for new/rename
-	ti := textinput.New()
-	ti.Placeholder = "session name"
-	ti.CharLimit = 64
+	// Every key of the list is ours, see listKeyMap
+	l.KeyMap = list.KeyMap{}
 
 	return Model{
-		client:    client,
-		list:      l,
-		state:     StateList,
-		textInput: ti,
-	}
+		client:       client,
+		config:       cfg,
+		collector:    proc.NewCollector(),
+		tracker:      watch.NewTracker(watch.DefaultOptions()),
+		notifiers:    desktopNotifiers(cfg.Hook),
+		matcher:      watch.NewMatcher(nil),
+		alerts:       make(map[string]int),
+		keys:         keys,
+		list:         l,
+		marked:       make(map[string]bool),
+		showUsage:    cfg.ShowColumn("usage"),
+		checkLogging: true,
+		sortMode:     parseSortMode(cfg.Sort),
+
+		profiles:       byobu.Profiles(),
+		defaultProfile: cfg.Profile,
+		state:          StateList,
+	}, nil
 }
 
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(loadSessions(m.client), tickCmd())
+	return tea.Batch(m.loadSessions(), tickCmd(m.config.Refresh))
 }
 
 // SelectedSession returns the session name to attach to (if any).
@@ -91,48 +261,150 @@ func (m Model) SelectedSession() string {
 	return m.selectedSession
 }
 
+// AttachOptions returns how to attach to the selected session.
+func (m Model) AttachOptions() byobu.AttachOptions {
+	return m.attachOptions
+}
+
+// ShareInstructions returns the share instructions to print on exit, if any.
+func (m Model) ShareInstructions() []string {
+	return m.shareLines
+}
+
 // tickMsg triggers a refresh.
 type tickMsg time.Time
 
 // sessionsLoadedMsg contains loaded sessions.
 type sessionsLoadedMsg struct {
 	sessions []byobu.Session
+	usage    resourceUsage
+	usageErr error           // Usage is best-effort and doesn't block the list
+	panesErr error           // Listing panes failed; usage, events and logging are missing
+	logging  map[string]bool // Logged sessions; nil if not checked
+	events   []watch.Event
 	err      error
 }
 
 // sessionActionMsg is the result of a session action (new/rename/kill).
 type sessionActionMsg struct {
-	err error
+	err       error
+	warning   string  // Non-fatal problem to show on the status line
+	attach    string  // Session to attach to after success
+	throwaway bool    // Destroy the attached session when its last client detaches
+	logging   bool    // Logging was toggled; check it on the next refresh
+	dialog    *dialog // Dialog the action was submitted from, if any
 }
 
-func tickCmd() tea.Cmd {
-	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
+func tickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
-func loadSessions(client byobu.Client) tea.Cmd {
+// loadOptions selects the optional data gathered on each refresh.
+type loadOptions struct {
+	collector *proc.Collector // Resource usage; nil to skip
+	tracker   *watch.Tracker  // Attention events; nil to skip
+	matcher   *watch.Matcher  // Output alert rules; nil to skip
+	logging   bool            // Which sessions are being logged
+}
+
+// needsPanes reports whether the pane list must be fetched.
+func (o loadOptions) needsPanes() bool {
+	return o.collector != nil || o.tracker != nil || o.matcher != nil || o.logging
+}
+
+// loadSessions loads sessions, plus resource usage when it is displayed or
+// used for sorting, attention events while watching, and output alerts
+// while rules are defined. Logging is checked on the first load, after
+// logging is toggled and while any session is being logged.
+func (m Model) loadSessions() tea.Cmd {
+	var opts loadOptions
+	if m.showUsage || m.sortMode.needsUsage() {
+		opts.collector = m.collector
+	}
+	if m.watching {
+		opts.tracker = m.tracker
+	}
+	if len(m.matcher.Rules()) > 0 {
+		opts.matcher = m.matcher
+	}
+	opts.logging = m.checkLogging || len(m.logging) > 0
+	return loadSessions(m.client, opts)
+}
+
+func loadSessions(client byobu.Client, opts loadOptions) tea.Cmd {
 	return func() tea.Msg {
 		sessions, err := client.ListSessions()
 		if err != nil {
 			return sessionsLoadedMsg{err: err}
 		}
 
-		// Load commands for each session
+		// Load commands and windows for each session
 		commands, _ := client.GetPaneCommands()
+		windows, _ := client.ListWindows()
+		bySession := make(map[string][]byobu.Window)
+		for _, w := range windows {
+			bySession[w.SessionName] = append(bySession[w.SessionName], w)
+		}
 		for i := range sessions {
 			if cmds, ok := commands[sessions[i].Name]; ok {
 				sessions[i].Commands = cmds
 			}
+			sessions[i].Windows = bySession[sessions[i].Name]
 		}
 
-		return sessionsLoadedMsg{sessions: sessions}
+		msg := sessionsLoadedMsg{sessions: sessions}
+		if !opts.needsPanes() {
+			return msg
+		}
+		panes, err := client.ListPanes()
+		if err != nil {
+			msg.panesErr = err
+			return msg
+		}
+		if opts.logging {
+			msg.logging = make(map[string]bool)
+			for _, p := range panes {
+				if p.Piped {
+					msg.logging[p.SessionName] = true
+				}
+			}
+		}
+		if opts.collector != nil {
+			msg.usage, msg.usageErr = collectUsage(panes, opts.collector)
+		}
+		now := time.Now()
+		if opts.tracker != nil {
+			msg.events = opts.tracker.Update(sessions, panes, now)
+		}
+		if opts.matcher != nil {
+			msg.events = append(msg.events, opts.matcher.Check(client, panes, now)...)
+		}
+		return msg
 	}
 }
 
 func (m *Model) updateSessionsPreserveSelection(sessions []byobu.Session) {
+	m.sortSessions(sessions)
 	m.sessions = sessions
 
+	// Forget marks on sessions that no longer exist
+	exists := make(map[string]bool, len(sessions))
+	for _, s := range sessions {
+		exists[s.Name] = true
+	}
+	for name := range m.marked {
+		if !exists[name] {
+			delete(m.marked, name)
+		}
+	}
+	for name := range m.alerts {
+		if !exists[name] {
+			delete(m.alerts, name)
+		}
+	}
+
 	items := make([]list.Item, len(sessions))
 	for i, s := range sessions {
 		items[i] = sessionItem{session: s}
@@ -148,9 +420,73 @@ func (m *Model) updateSessionsPreserveSelection(sessions []byobu.Session) {
 	}
 }
 
+// sessionNames returns the names of all listed sessions.
+func (m Model) sessionNames() []string {
+	names := make([]string, len(m.sessions))
+	for i, s := range m.sessions {
+		names[i] = s.Name
+	}
+	return names
+}
+
 func (m Model) currentSession() (byobu.Session, bool) {
 	if item, ok := m.list.SelectedItem().(sessionItem); ok {
 		return item.session, true
 	}
 	return byobu.Session{}, false
 }
+
+// sortSessions orders sessions in place according to the current sort mode.
+func (m Model) sortSessions(sessions []byobu.Session) {
+	usage := m.usage.sessions
+	switch m.sortMode {
+	case SortCPU:
+		sort.SliceStable(sessions, func(i, j int) bool {
+			return usage[sessions[i].Name].CPU > usage[sessions[j].Name].CPU
+		})
+	case SortMemory:
+		sort.SliceStable(sessions, func(i, j int) bool {
+			return usage[sessions[i].Name].RSS > usage[sessions[j].Name].RSS
+		})
+	case SortActivity:
+		sort.SliceStable(sessions, func(i, j int) bool {
+			return sessions[i].Activity.After(sessions[j].Activity)
+		})
+	default:
+		// Keep the sessions of a group together
+		sort.SliceStable(sessions, func(i, j int) bool {
+			gi, gj := groupKey(sessions[i]), groupKey(sessions[j])
+			if gi != gj {
+				return gi < gj
+			}
+			return sessions[i].Name < sessions[j].Name
+		})
+	}
+}
+
+// groupMembers returns the names of the sessions in a session group.
+func (m Model) groupMembers(group string) []string {
+	var names []string
+	for _, s := range m.sessions {
+		if s.Grouped && s.Group == group {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// groupKey returns the name a session sorts under: its group's, if grouped.
+func groupKey(s byobu.Session) string {
+	if s.Grouped {
+		return s.Group
+	}
+	return s.Name
+}
+
+// resort reapplies the sort mode while keeping the cursor on the same session.
+func (m *Model) resort() {
+	if session, ok := m.currentSession(); ok {
+		m.selectedName = session.Name
+	}
+	m.updateSessionsPreserveSelection(m.sessions)
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Fields of the new session dialog.
+const (
+	newFieldName = iota
+	newFieldDir
+	newFieldCommand
+	newFieldWindow
+	newFieldEnv
+	newFieldProfile
+	newFieldAttach
+)
+
+func (m Model) openNewSession() (tea.Model, tea.Cmd) {
+	profiles := []string{"none"}
+	for _, p := range m.profiles {
+		profiles = append(profiles, p.Name)
+	}
+	m.suggestedName = ""
+	name := textField("Name", "session name", "")
+	name.input.CharLimit = byobu.MaxSessionNameLength
+	m.dialog = newDialog("New session", "[Tab] field  [←/→] choose  [Enter] create  [Esc] cancel",
+		name,
+		textField("Directory", "start directory (default: current)", ""),
+		textField("Command", "run instead of a shell", ""),
+		textField("Window", "first window name", ""),
+		textField("Env", "KEY=VALUE ...", ""),
+		choiceField("Profile", profiles, m.defaultProfile),
+		choiceField("Attach", []string{"no", "yes"}, "no"),
+	)
+	m.state = StateNewSession
+	return m, suggestName(m.dialog, "", m.sessionNames())
+}
+
+func (m Model) handleNewSession(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		return m.submitNewSession()
+	}
+
+	dir := m.dialog.value(newFieldDir)
+	cmd := m.dialog.update(msg)
+	if d := m.dialog.value(newFieldDir); d != dir {
+		cmd = tea.Batch(cmd, suggestName(m.dialog, d, m.sessionNames()))
+	}
+	m.dialog.message = m.nameProblem(m.dialog.value(newFieldName), "")
+	return m, cmd
+}
+
+// submitNewSession validates the new session dialog and creates the session.
+func (m Model) submitNewSession() (tea.Model, tea.Cmd) {
+	fail := func(field int, problem string) (tea.Model, tea.Cmd) {
+		m.dialog.setFocus(field)
+		m.dialog.fail(problem)
+		return m, nil
+	}
+
+	name := m.dialog.value(newFieldName)
+	if problem := m.nameProblem(name, ""); problem != "" {
+		return fail(newFieldName, problem)
+	}
+	attach := m.dialog.value(newFieldAttach) == "yes"
+	if attach && name == "" {
+		return fail(newFieldName, "a name is needed to attach")
+	}
+
+	opts := byobu.NewSessionOptions{
+		StartDir:   expandHome(m.dialog.value(newFieldDir)),
+		Command:    m.dialog.value(newFieldCommand),
+		WindowName: m.dialog.value(newFieldWindow),
+		Env:        strings.Fields(m.dialog.value(newFieldEnv)),
+	}
+	if opts.StartDir != "" {
+		if info, err := os.Stat(opts.StartDir); err != nil || !info.IsDir() {
+			return fail(newFieldDir, fmt.Sprintf("'%s' is not a directory", opts.StartDir))
+		}
+	}
+	if err := byobu.ValidateEnv(opts.Env); err != nil {
+		return fail(newFieldEnv, err.Error())
+	}
+
+	var profile *byobu.Profile
+	if p, err := byobu.FindProfile(m.profiles, m.dialog.value(newFieldProfile)); err == nil {
+		profile = &p
+	}
+	m.selectedName = name
+	return m, m.dialog.submit(newSession(m.client, name, opts, profile, attach))
+}
+
+// nameSuggestedMsg carries a session name suggested for a start directory.
+type nameSuggestedMsg struct {
+	dialog *dialog // New session dialog the suggestion was made for
+	dir    string  // Directory field at the time, "" for the current directory
+	name   string  // Suggested name, "" if dir is not a directory
+}
+
+// suggestName suggests a session name for dir in the background, since it
+// runs git.
+func suggestName(d *dialog, dir string, existing []string) tea.Cmd {
+	return func() tea.Msg {
+		msg := nameSuggestedMsg{dialog: d, dir: dir}
+		path := expandHome(dir)
+		if path == "" {
+			path, _ = os.Getwd()
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			msg.name = byobu.SuggestSessionName(path, existing)
+		}
+		return msg
+	}
+}
+
+// handleNameSuggested pre-fills the suggested name, unless the name was
+// edited by hand or the suggestion is for a directory no longer entered.
+func (m Model) handleNameSuggested(msg nameSuggestedMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateNewSession || msg.dialog != m.dialog || msg.name == "" ||
+		msg.dir != m.dialog.value(newFieldDir) || m.dialog.value(newFieldName) != m.suggestedName {
+		return m, nil
+	}
+	m.suggestedName = msg.name
+	m.dialog.fields[newFieldName].input.SetValue(msg.name)
+	m.dialog.message = m.nameProblem(msg.name, "")
+	return m, nil
+}
+
+// nameProblem describes why name can't be used for a session, or returns
+// "" if it can. current is the session's present name when renaming.
+func (m Model) nameProblem(name, current string) string {
+	if name == current {
+		return ""
+	}
+	if err := byobu.ValidateSessionName(name); err != nil {
+		return err.Error()
+	}
+	for _, s := range m.sessions {
+		if s.Name == name {
+			return fmt.Sprintf("session '%s' already exists", name)
+		}
+	}
+	return ""
+}
+
+// newSession creates a session and applies profile, then attaches to it if
+// attach is set.
+func newSession(client byobu.Client, name string, opts byobu.NewSessionOptions, profile *byobu.Profile, attach bool) tea.Cmd {
+	return func() tea.Msg {
+		err := client.NewSession(name, opts)
+		if err != nil {
+			return sessionActionMsg{err: err}
+		}
+		// Apply the option profile (minimal status bar, mouse, ...). The
+		// session exists either way, so a failure is only reported, and
+		// byoman stays open instead of attaching so it can be read.
+		if profile != nil {
+			if err := client.ApplyProfile(name, *profile); err != nil {
+				return sessionActionMsg{warning: fmt.Sprintf("profile '%s' not fully applied: %s", profile.Name, err)}
+			}
+		}
+		msg := sessionActionMsg{err: nil}
+		if attach {
+			msg.attach = name
+		}
+		return msg
+	}
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// profileClient creates sessions and fails to apply profiles when err is
+// set; other Client methods are not used.
+type profileClient struct {
+	byobu.Client
+	err error
+}
+
+func (c profileClient) NewSession(name string, opts byobu.NewSessionOptions) error { return nil }
+
+func (c profileClient) ApplyProfile(sessionName string, profile byobu.Profile) error { return c.err }
+
+func TestNewSessionProfileWarning(t *testing.T) {
+	profile := &byobu.Profile{Name: "mobile"}
+	tests := []struct {
+		name       string
+		err        error
+		attach     string
+		wantNotice string
+	}{
+		{"applied", nil, "api", ""},
+		{"failed", errors.New("invalid option: mouse"), "", "profile 'mobile' not fully applied: invalid option: mouse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := profileClient{err: tt.err}
+			msg := newSession(client, "api", byobu.NewSessionOptions{}, profile, true)().(sessionActionMsg)
+			if msg.err != nil || msg.attach != tt.attach || msg.warning != tt.wantNotice {
+				t.Errorf("got %+v", msg)
+			}
+
+			m := testModel(t, 80, 24, 1)
+			updated, _ := m.Update(msg)
+			got := updated.(Model)
+			if tt.wantNotice != "" && !strings.Contains(got.View(), tt.wantNotice) {
+				t.Errorf("warning not shown on the status line: %q", got.notice)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionOptionsMsg contains the option overrides of one session.
+type sessionOptionsMsg struct {
+	session string
+	options []byobu.Option
+	err     error
+}
+
+func loadSessionOptions(client byobu.Client, session string) tea.Cmd {
+	return func() tea.Msg {
+		options, err := client.SessionOptions(session)
+		return sessionOptionsMsg{session: session, options: options, err: err}
+	}
+}
+
+// applyProfile applies profile to every named session.
+func applyProfile(client byobu.Client, names []string, profile byobu.Profile) tea.Cmd {
+	return func() tea.Msg {
+		for _, name := range names {
+			if err := client.ApplyProfile(name, profile); err != nil {
+				return sessionActionMsg{err: err}
+			}
+		}
+		return sessionActionMsg{}
+	}
+}
+
+func (m Model) handleSessionOptions(msg sessionOptionsMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	if m.state != StateList && m.state != StateOptions {
+		return m, nil
+	}
+	m.optionsSession = msg.session
+	m.sessionOptions = msg.options
+	m.state = StateOptions
+	return m, nil
+}
+
+// profileTargets returns the marked sessions, or the inspected one.
+func (m Model) profileTargets() []string {
+	if marked := m.markedSessions(); len(marked) > 0 {
+		return marked
+	}
+	return []string{m.optionsSession}
+}
+
+func (m Model) handleOptionsState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.profileCursor > 0 {
+			m.profileCursor--
+		}
+	case "down", "j":
+		if m.profileCursor < len(m.profiles)-1 {
+			m.profileCursor++
+		}
+	case "enter":
+		if m.profileCursor < len(m.profiles) {
+			return m, tea.Sequence(
+				applyProfile(m.client, m.profileTargets(), m.profiles[m.profileCursor]),
+				loadSessionOptions(m.client, m.optionsSession),
+			)
+		}
+	case "esc", "q":
+		m.state = StateList
+		m.sessionOptions = nil
+	}
+	return m, nil
+}
+
+func (m Model) renderOptions() string {
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render(fmt.Sprintf("Options for '%s'", m.optionsSession)))
+	b.WriteString("\n\n")
+
+	b.WriteString(PromptStyle.Render("Session overrides"))
+	b.WriteString("\n")
+	if len(m.sessionOptions) == 0 {
+		b.WriteString(DimStyle.Render("  none (all options inherited from byobu)"))
+		b.WriteString("\n")
+	}
+	for _, o := range m.sessionOptions {
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", o.Name, DimStyle.Render(o.Value)))
+	}
+
+	b.WriteString("\n")
+	targets := m.profileTargets()
+	if len(targets) > 1 {
+		b.WriteString(PromptStyle.Render(fmt.Sprintf("Apply profile to %d marked sessions", len(targets))))
+	} else {
+		b.WriteString(PromptStyle.Render("Apply profile"))
+	}
+	b.WriteString("\n")
+	for i, p := range m.profiles {
+		cursor := "  "
+		name := fmt.Sprintf("%-10s", p.Name)
+		if i == m.profileCursor {
+			cursor = CursorStyle.Render("> ")
+			name = SelectedItemStyle.Render(name)
+		}
+		b.WriteString(fmt.Sprintf("%s%s  %s\n", cursor, name, DimStyle.Render(p.Description)))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("[enter]apply  [esc]back"))
+	return b.String()
+}
@@ -0,0 +1,243 @@
+package tui
+
+import (
+	"byoman/internal/config"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sahilm/fuzzy"
+)
+
+// paletteRows is how many matches the command palette shows at once.
+const paletteRows = 10
+
+// paletteCommand is an entry of the command palette.
+type paletteCommand struct {
+	title string
+	keys  string // Key label shown next to the title, "" for none
+	run   func(m Model) (tea.Model, tea.Cmd)
+}
+
+// paletteCommands is a list of commands searchable with fuzzy.
+type paletteCommands []paletteCommand
+
+func (c paletteCommands) String(i int) string { return c[i].title }
+func (c paletteCommands) Len() int            { return len(c) }
+
+// paletteCommands returns every command: the list's actions, including
+// those without a key, choices that have no key of their own, and the
+// user's commands.
+func (m Model) paletteCommands() paletteCommands {
+	actions := m.keys.actions()
+	titled := []struct{ title, action string }{
+		{"Attach", "attach"},
+		{"Attach and detach other clients", "attach-exclusive"},
+		{"Attach read-only", "attach-read-only"},
+		{"Open a throwaway view of the session", "view"},
+		{"Share session read-only", "share"},
+		{"New session", "new"},
+		{"Rename session", "rename"},
+		{"Kill session", "kill"},
+		{"Send keys to session", "exec"},
+		{"Mark session", "mark"},
+		{"Broadcast to sessions", "broadcast"},
+		{"Toggle minimal status bar", "status-bar"},
+		{"Session options and profiles", "options"},
+		{"Windows", "windows"},
+		{"Panes and processes", "panes"},
+		{"Attached clients", "clients"},
+		{"Toggle logging", "log"},
+		{"Browse logs", "logs"},
+		{"Toggle resource usage", "usage"},
+		{"Toggle watching for attention", "watch"},
+		{"Output alert rules", "alerts"},
+		{"Clear alerts", "clear-alerts"},
+		{"Show all keys", "help"},
+		{"Quit", "quit"},
+	}
+
+	var cmds paletteCommands
+	for _, c := range titled {
+		action := c.action
+		var keys string
+		if b := actions[action]; b.Enabled() {
+			keys = b.Help().Key
+		}
+		cmds = append(cmds, paletteCommand{
+			title: c.title,
+			keys:  keys,
+			run:   func(m Model) (tea.Model, tea.Cmd) { return m.runListAction(action) },
+		})
+	}
+	for s := SortDefault; s <= SortActivity; s++ {
+		mode := s
+		cmds = append(cmds, paletteCommand{
+			title: "Sort by " + mode.String(),
+			run: func(m Model) (tea.Model, tea.Cmd) {
+				m.sortMode = mode
+				m.resort()
+				return m, m.loadSessions()
+			},
+		})
+	}
+	for _, c := range m.config.Commands {
+		command := c
+		cmds = append(cmds, paletteCommand{
+			title: command.Name,
+			run: func(m Model) (tea.Model, tea.Cmd) {
+				session, ok := m.currentSession()
+				if !ok {
+					m.err = fmt.Errorf("%s: no session selected", command.Name)
+					return m, nil
+				}
+				return m, runUserCommand(command, session.Name)
+			},
+		})
+	}
+	return cmds
+}
+
+// openPalette opens the command palette over the list.
+func (m Model) openPalette() (tea.Model, tea.Cmd) {
+	m.palette = m.paletteCommands()
+	m.paletteCursor = 0
+	m.state = StatePalette
+	m.dialog = newDialog("Commands", "[↑/↓] select  [Enter] run  [Esc] cancel",
+		textField(">", "type to search", ""))
+	m.updatePaletteBody()
+	return m, nil
+}
+
+// paletteMatches returns the commands matching the search, best first.
+func (m Model) paletteMatches() fuzzy.Matches {
+	return filterPalette(m.palette, m.dialog.value(0))
+}
+
+// filterPalette returns the commands matching query, best first. An empty
+// query lists every command in order.
+func filterPalette(commands paletteCommands, query string) fuzzy.Matches {
+	if query == "" {
+		matches := make(fuzzy.Matches, len(commands))
+		for i, c := range commands {
+			matches[i] = fuzzy.Match{Str: c.title, Index: i}
+		}
+		return matches
+	}
+	return fuzzy.FindFrom(query, commands)
+}
+
+func (m Model) handlePaletteState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := m.paletteMatches()
+
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "up", "ctrl+p", "shift+tab":
+		if m.paletteCursor > 0 {
+			m.paletteCursor--
+		}
+		m.updatePaletteBody()
+		return m, nil
+	case "down", "ctrl+n", "tab":
+		if m.paletteCursor < len(matches)-1 {
+			m.paletteCursor++
+		}
+		m.updatePaletteBody()
+		return m, nil
+	case "enter":
+		if m.paletteCursor >= len(matches) {
+			return m, nil
+		}
+		command := m.palette[matches[m.paletteCursor].Index]
+		m.closeDialog()
+		return command.run(m)
+	}
+
+	cmd := m.dialog.update(msg)
+	m.paletteCursor = 0
+	m.updatePaletteBody()
+	return m, cmd
+}
+
+// updatePaletteBody renders the matches around the cursor, with the
+// matched characters highlighted.
+func (m *Model) updatePaletteBody() {
+	matches := m.paletteMatches()
+	if len(matches) == 0 {
+		m.dialog.body = []string{DimStyle.Render("No matching command.")}
+		return
+	}
+	m.paletteCursor = min(m.paletteCursor, len(matches)-1)
+	start := max(m.paletteCursor-paletteRows+1, 0)
+	end := min(start+paletteRows, len(matches))
+
+	m.dialog.body = nil
+	for i := start; i < end; i++ {
+		match := matches[i]
+		highlight := make(map[int]bool, len(match.MatchedIndexes))
+		for _, j := range match.MatchedIndexes {
+			highlight[j] = true
+		}
+		var title strings.Builder
+		for j, r := range match.Str {
+			if highlight[j] {
+				title.WriteString(PromptStyle.Render(string(r)))
+			} else {
+				title.WriteRune(r)
+			}
+		}
+
+		cursor := "  "
+		if i == m.paletteCursor {
+			cursor = CursorStyle.Render("> ")
+		}
+		line := cursor + title.String()
+		if keys := m.palette[match.Index].keys; keys != "" {
+			line += "  " + DimStyle.Render(keys)
+		}
+		m.dialog.body = append(m.dialog.body, line)
+	}
+	if len(matches) > end {
+		m.dialog.body = append(m.dialog.body, DimStyle.Render(fmt.Sprintf("  … %d more", len(matches)-end)))
+	}
+}
+
+// userCommandMsg is the result of a user-defined command.
+type userCommandMsg struct {
+	name   string
+	output string
+	err    error
+}
+
+// runUserCommand runs a user-defined command via sh -c, passing the
+// selected session in BYOMAN_SESSION.
+func runUserCommand(command config.Command, session string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := exec.Command("sh", "-c", command.Run)
+		cmd.Env = append(os.Environ(), "BYOMAN_SESSION="+session)
+		out, err := cmd.CombinedOutput()
+		return userCommandMsg{name: command.Name, output: strings.TrimSpace(string(out)), err: err}
+	}
+}
+
+// handleUserCommandDone reports a user command's result on the status
+// line: the last line of output on failure, else the first.
+func (m Model) handleUserCommandDone(msg userCommandMsg) (tea.Model, tea.Cmd) {
+	lines := strings.Split(msg.output, "\n")
+	if msg.err != nil {
+		m.err = fmt.Errorf("%s: %v", msg.name, msg.err)
+		if msg.output != "" {
+			m.err = fmt.Errorf("%s: %s", msg.name, lines[len(lines)-1])
+		}
+		return m, m.loadSessions()
+	}
+	m.notice = msg.name + ": done"
+	if msg.output != "" {
+		m.notice = msg.name + ": " + lines[0]
+	}
+	return m, m.loadSessions()
+}
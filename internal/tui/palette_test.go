@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"byoman/internal/config"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilterPalette(t *testing.T) {
+	cfg := config.Default()
+	cfg.Theme = "dark"
+	cfg.Keys = map[string][]string{"watch": nil}
+	cfg.Commands = []config.Command{{Name: "Pull", Run: "git pull"}}
+	m, err := NewModel(nil, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands := m.paletteCommands()
+
+	tests := []struct {
+		query string
+		first string // Best match, "" for none
+	}{
+		{"kil", "Kill session"},
+		{"sort mem", "Sort by memory"},
+		{"sort cpu", "Sort by cpu"},
+		{"ren", "Rename session"},
+		{"watch", "Toggle watching for attention"},
+		{"pull", "Pull"},
+		{"zzz", ""},
+	}
+	for _, tt := range tests {
+		matches := filterPalette(commands, tt.query)
+		if tt.first == "" {
+			if len(matches) != 0 {
+				t.Errorf("filterPalette(%q) = %d matches, want none", tt.query, len(matches))
+			}
+			continue
+		}
+		if len(matches) == 0 || commands[matches[0].Index].title != tt.first {
+			t.Errorf("filterPalette(%q) best match = %v, want %q", tt.query, matches, tt.first)
+		}
+	}
+
+	if got := filterPalette(commands, ""); len(got) != len(commands) {
+		t.Errorf("empty query matches %d of %d commands", len(got), len(commands))
+	}
+
+	// Actions whose keys were removed stay in the palette without a key
+	for _, c := range commands {
+		switch c.title {
+		case "Toggle watching for attention":
+			if c.keys != "" {
+				t.Errorf("%q shows key %q after its keys were removed", c.title, c.keys)
+			}
+		case "Kill session":
+			if c.keys == "" {
+				t.Errorf("%q shows no key", c.title)
+			}
+		}
+	}
+}
+
+func TestPaletteRunsAction(t *testing.T) {
+	tests := []struct {
+		query string
+		check func(m Model) bool
+	}{
+		{"sort mem", func(m Model) bool { return m.sortMode == SortMemory }},
+		{"show all keys", func(m Model) bool { return m.showHelp }},
+		{"windows", func(m Model) bool { return m.state == StateWindows }},
+	}
+	for _, tt := range tests {
+		m := testModel(t, 80, 24, 3)
+		updated, _ := m.openPalette()
+		for _, r := range tt.query {
+			updated, _ = updated.(Model).handlePaletteState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		updated, _ = updated.(Model).handlePaletteState(tea.KeyMsg{Type: tea.KeyEnter})
+		got := updated.(Model)
+		if got.dialog != nil {
+			t.Errorf("%q: palette still open", tt.query)
+		}
+		if !tt.check(got) {
+			t.Errorf("%q: action not run", tt.query)
+		}
+	}
+}
+
+func TestUserCommandNeedsSession(t *testing.T) {
+	for _, n := range []int{0, 1} {
+		m := testModel(t, 80, 24, n)
+		m.config.Commands = []config.Command{{Name: "Pull", Run: "true"}}
+		var found bool
+		for _, c := range m.paletteCommands() {
+			if c.title != "Pull" {
+				continue
+			}
+			found = true
+			updated, cmd := c.run(m)
+			if n == 0 {
+				err := updated.(Model).err
+				if cmd != nil || err == nil || !strings.Contains(err.Error(), "no session selected") {
+					t.Errorf("without a session: err = %v, cmd = %v", err, cmd != nil)
+				}
+			} else if cmd == nil {
+				t.Error("with a session: command not run")
+			}
+		}
+		if !found {
+			t.Fatal("user command missing from the palette")
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Choices of the split dialog's direction field.
+var splitDirections = []string{"side by side", "stacked"}
+
+// paneLabel describes a pane as session:window.pane.
+func paneLabel(p byobu.Pane) string {
+	return fmt.Sprintf("%s:%d.%d", p.SessionName, p.WindowIndex, p.Index)
+}
+
+// handlePaneAction handles the pane picker keys that change panes.
+func (m Model) handlePaneAction(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if len(m.panes) == 0 {
+		return m, nil
+	}
+	p := m.currentPane()
+	label := paneLabel(p)
+
+	switch msg.String() {
+	case "|", "-":
+		direction := splitDirections[0]
+		if msg.String() == "-" {
+			direction = splitDirections[1]
+		}
+		m.state = StateSplitPane
+		m.dialog = newDialog("Split pane "+label, "[Tab] field  [←/→] direction  [Enter] split  [Esc] cancel",
+			textField("Directory", "start directory (default: session's)", p.CurrentPath),
+			textField("Command", "run instead of a shell", ""),
+			choiceField("Direction", splitDirections, direction),
+		)
+	case "d":
+		m.state = StateKillPane
+		m.dialog = newDialog("Kill pane", "[y/Enter] kill  [n/Esc] cancel")
+		m.dialog.body = []string{fmt.Sprintf("Kill pane %s (%s)?", label, p.CurrentCommand)}
+	case "b":
+		return m, paneAction(m.client, p.SessionName, func() error { return m.client.BreakPane(p.ID, p.SessionName) })
+	case "z":
+		return m, paneAction(m.client, p.SessionName, func() error { return m.client.ZoomPane(p.ID) })
+	case "J":
+		m.joinTargets = nil
+		var labels []string
+		for _, s := range m.sessions {
+			for _, w := range s.Windows {
+				if w.ID != p.WindowID {
+					m.joinTargets = append(m.joinTargets, w)
+					labels = append(labels, fmt.Sprintf("%s:%d %s", w.SessionName, w.Index, w.Name))
+				}
+			}
+		}
+		if len(labels) == 0 {
+			m.err = fmt.Errorf("no other window to join pane %s into", label)
+			return m, nil
+		}
+		m.state = StateJoinPane
+		m.dialog = newDialog("Join pane "+label, "[←/→] window  [Enter] join  [Esc] cancel",
+			choiceField("Into window", labels, ""))
+	case "a":
+		m.state = StateSelectLayout
+		m.dialog = newDialog(fmt.Sprintf("Arrange window %s:%d", p.SessionName, p.WindowIndex), "[←/→] layout  [Enter] apply  [Esc] cancel",
+			choiceField("Layout", byobu.Layouts, ""))
+	}
+	return m, nil
+}
+
+func (m Model) handleSplitPane(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		opts := byobu.SplitOptions{
+			StartDir:   expandHome(m.dialog.value(0)),
+			Command:    m.dialog.value(1),
+			Horizontal: m.dialog.value(2) == splitDirections[0],
+		}
+		if opts.StartDir != "" {
+			if info, err := os.Stat(opts.StartDir); err != nil || !info.IsDir() {
+				m.dialog.setFocus(0)
+				m.dialog.fail(fmt.Sprintf("'%s' is not a directory", opts.StartDir))
+				return m, nil
+			}
+		}
+		id := m.currentPane().ID
+		return m, m.dialog.submit(runAction(func() error { return m.client.SplitPane(id, opts) }))
+	}
+	return m, m.dialog.update(msg)
+}
+
+func (m Model) handleKillPane(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		id := m.currentPane().ID
+		return m, m.dialog.submit(runAction(func() error { return m.client.KillPane(id) }))
+	case "n", "N", "esc":
+		m.closeDialog()
+	}
+	return m, nil
+}
+
+func (m Model) handleJoinPane(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		id := m.currentPane().ID
+		dst := m.joinTargets[m.dialog.fields[0].choice].ID
+		return m, m.dialog.submit(runAction(func() error { return m.client.JoinPane(id, dst) }))
+	}
+	return m, m.dialog.update(msg)
+}
+
+func (m Model) handleSelectLayout(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		window := m.currentPane().WindowID
+		layout := m.dialog.value(0)
+		return m, m.dialog.submit(runAction(func() error { return m.client.SelectLayout(window, layout) }))
+	}
+	return m, m.dialog.update(msg)
+}
+
+// paneAction runs a pane command, then reloads the session's panes.
+func paneAction(client byobu.Client, session string, action func() error) tea.Cmd {
+	return tea.Sequence(runAction(action), loadPanes(client, session))
+}
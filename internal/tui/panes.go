@@ -0,0 +1,296 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"byoman/internal/proc"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// panesLoadedMsg contains the panes of one session.
+type panesLoadedMsg struct {
+	session string
+	panes   []byobu.Pane
+	err     error
+}
+
+// processTreeMsg contains the process tree of one pane.
+type processTreeMsg struct {
+	paneID string
+	nodes  []proc.Node
+	err    error
+}
+
+func loadPanes(client byobu.Client, session string) tea.Cmd {
+	return func() tea.Msg {
+		all, err := client.ListPanes()
+		if err != nil {
+			return panesLoadedMsg{session: session, err: err}
+		}
+		var panes []byobu.Pane
+		for _, p := range all {
+			if p.SessionName == session {
+				panes = append(panes, p)
+			}
+		}
+		return panesLoadedMsg{session: session, panes: panes}
+	}
+}
+
+func loadProcessTree(pane byobu.Pane) tea.Cmd {
+	return func() tea.Msg {
+		nodes, err := proc.ReadTree(pane.PID)
+		return processTreeMsg{paneID: pane.ID, nodes: nodes, err: err}
+	}
+}
+
+func sendSignal(pid int, sig syscall.Signal) tea.Cmd {
+	return func() tea.Msg {
+		return sessionActionMsg{err: proc.Signal(pid, sig)}
+	}
+}
+
+func (m Model) currentPane() byobu.Pane {
+	if m.paneCursor >= 0 && m.paneCursor < len(m.panes) {
+		return m.panes[m.paneCursor]
+	}
+	return byobu.Pane{}
+}
+
+func (m Model) handlePanesLoaded(msg panesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	if len(msg.panes) == 0 {
+		m.err = fmt.Errorf("session '%s' has no panes", msg.session)
+		if m.state == StatePanes {
+			// The last pane was killed, taking the session with it
+			m.state = StateList
+			m.panes = nil
+		}
+		return m, nil
+	}
+	// Keep the cursor when reloading the panes being viewed
+	if m.state != StatePanes || m.currentPane().SessionName != msg.session {
+		m.paneCursor = 0
+	}
+	m.panes = msg.panes
+	if m.paneCursor >= len(m.panes) {
+		m.paneCursor = len(m.panes) - 1
+	}
+	m.state = StatePanes
+	return m, nil
+}
+
+func (m Model) handleProcessTree(msg processTreeMsg) (tea.Model, tea.Cmd) {
+	if msg.paneID != m.currentPane().ID {
+		return m, nil // Stale result for a pane we've left
+	}
+	if msg.err != nil {
+		m.err = msg.err
+		if m.state == StateProcesses {
+			m.state = StatePanes
+		}
+		return m, nil
+	}
+
+	// Keep the cursor on the same PID across refreshes
+	selected := 0
+	if m.procCursor < len(m.procNodes) {
+		pid := m.procNodes[m.procCursor].PID
+		for i, n := range msg.nodes {
+			if n.PID == pid {
+				selected = i
+				break
+			}
+		}
+	}
+	m.procNodes = msg.nodes
+	m.procCursor = selected
+	if m.state == StatePanes {
+		m.state = StateProcesses
+	}
+	return m, nil
+}
+
+func (m Model) handlePanesState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.paneCursor > 0 {
+			m.paneCursor--
+		}
+	case "down", "j":
+		if m.paneCursor < len(m.panes)-1 {
+			m.paneCursor++
+		}
+	case "enter":
+		m.procNodes = nil
+		m.procCursor = 0
+		return m, loadProcessTree(m.currentPane())
+	case "x":
+		p := m.currentPane()
+		return m.promptSendKeys(p.ID, fmt.Sprintf("pane %s:%d.%d", p.SessionName, p.WindowIndex, p.Index))
+	case "X":
+		p := m.currentPane()
+		return m.promptSendKeys(p.WindowID, fmt.Sprintf("window %s:%d", p.SessionName, p.WindowIndex))
+	case "l":
+		id := m.currentPane().ID
+		return m, tea.Sequence(
+			toggleLogging(m.client, func(p byobu.Pane) bool { return p.ID == id }),
+			loadPanes(m.client, m.currentPane().SessionName),
+		)
+	case "L":
+		id := m.currentPane().WindowID
+		return m, tea.Sequence(
+			toggleLogging(m.client, func(p byobu.Pane) bool { return p.WindowID == id }),
+			loadPanes(m.client, m.currentPane().SessionName),
+		)
+	case "esc", "q":
+		m.state = StateList
+		m.panes = nil
+	default:
+		return m.handlePaneAction(msg)
+	}
+	return m, nil
+}
+
+func (m Model) handleProcessesState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.procCursor > 0 {
+			m.procCursor--
+		}
+	case "down", "j":
+		if m.procCursor < len(m.procNodes)-1 {
+			m.procCursor++
+		}
+	case "i":
+		return m.confirmSignal(syscall.SIGINT)
+	case "t":
+		return m.confirmSignal(syscall.SIGTERM)
+	case "esc", "q":
+		m.state = StatePanes
+		m.procNodes = nil
+	}
+	return m, nil
+}
+
+func (m Model) confirmSignal(sig syscall.Signal) (tea.Model, tea.Cmd) {
+	if m.procCursor >= len(m.procNodes) {
+		return m, nil
+	}
+	m.signalTarget = m.procNodes[m.procCursor]
+	m.signal = sig
+	m.state = StateConfirmSignal
+	m.dialog = newDialog("Send signal", "[y/Enter] send  [n/Esc] cancel")
+	m.dialog.body = []string{fmt.Sprintf("Send %s to %d (%s)?",
+		proc.SignalName(sig), m.signalTarget.PID, m.signalTarget.Comm)}
+	return m, nil
+}
+
+func (m Model) handleConfirmSignal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		return m, m.dialog.submit(sendSignal(m.signalTarget.PID, m.signal))
+	case "n", "N", "esc":
+		m.closeDialog()
+	}
+	return m, nil
+}
+
+func (m Model) renderPanes() string {
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render(fmt.Sprintf("Panes in '%s'", m.currentPane().SessionName)))
+	b.WriteString("\n\n")
+
+	for i, p := range m.panes {
+		cursor := "  "
+		target := fmt.Sprintf("%-6s", fmt.Sprintf("%d.%d", p.WindowIndex, p.Index))
+		if i == m.paneCursor {
+			cursor = CursorStyle.Render("> ")
+			target = SelectedItemStyle.Render(target)
+		}
+
+		line := fmt.Sprintf("%s%s  %-10s  %s", cursor, target, p.CurrentCommand, DimStyle.Render(p.CurrentPath))
+		if p.Piped {
+			line += "  " + LoggingStyle.Render("[log]")
+		}
+		if p.Zoomed {
+			line += "  " + DimStyle.Render("[zoomed]")
+		}
+		if m.showUsage {
+			u := m.usage.panes[p.ID]
+			line += "  " + DimStyle.Render(fmt.Sprintf("%s %7s", formatCPU(u.CPU), formatBytes(u.RSS)))
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("[enter]processes  e[x]ec in pane  [X] exec in window  [l]og pane  [L]og window  [esc]back"))
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render("[|] split side by side  [-] split stacked  [d]elete  [b]reak out  [J]oin  [z]oom  [a]rrange"))
+	return b.String()
+}
+
+func (m Model) renderProcesses() string {
+	pane := m.currentPane()
+
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render(fmt.Sprintf("Processes in %s:%d.%d", pane.SessionName, pane.WindowIndex, pane.Index)))
+	b.WriteString("\n\n")
+	b.WriteString(DimStyle.Render(fmt.Sprintf("  %-7s %-2s %9s  %s", "PID", "S", "ELAPSED", "COMMAND")))
+	b.WriteString("\n")
+
+	for i, n := range m.procNodes {
+		cursor := "  "
+		if i == m.procCursor {
+			cursor = CursorStyle.Render("> ")
+		}
+
+		command := strings.Repeat("  ", n.Depth) + n.Cmdline
+		if i == m.procCursor {
+			command = SelectedItemStyle.Render(command)
+		}
+
+		line := fmt.Sprintf("%s%-7d %-2s %9s  %s", cursor, n.PID, n.State, formatElapsed(n.Elapsed), command)
+		if m.width > 0 {
+			line = ansi.Truncate(line, m.width, "…")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("[i] SIGINT  [t] SIGTERM  [esc]back"))
+	return b.String()
+}
+
+// formatElapsed renders a duration compactly, e.g. "3d04h", "2h05m", "4m12s".
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%02dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%02ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// shareInfoLoadedMsg contains what is needed to share a session.
+type shareInfoLoadedMsg struct {
+	info byobu.ShareInfo
+	err  error
+}
+
+func loadShareInfo(client byobu.Client, session string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := client.ShareInfo(session)
+		return shareInfoLoadedMsg{info: info, err: err}
+	}
+}
+
+func (m Model) handleShareInfoLoaded(msg shareInfoLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.shareInfo = msg.info
+	m.state = StateShare
+	m.dialog = newDialog(fmt.Sprintf("Share '%s' read-only", msg.info.Session), "[Enter] print & quit  [Esc] close",
+		textField("Share with", "local user name", ""))
+	m.updateShareBody()
+	return m, nil
+}
+
+func (m Model) handleShareState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		// Print the instructions once the alternate screen is gone, so they
+		// can be copied
+		m.shareLines = m.shareInfo.Instructions(m.dialog.value(0))
+		m.quitting = true
+		return m, tea.Quit
+	}
+	cmd := m.dialog.update(msg)
+	m.updateShareBody()
+	return m, cmd
+}
+
+// updateShareBody renders the instructions for the user typed so far.
+func (m *Model) updateShareBody() {
+	m.dialog.body = m.shareInfo.Instructions(m.dialog.value(0))
+	m.dialog.width = 0
+	for _, line := range m.dialog.body {
+		m.dialog.width = max(m.dialog.width, lipgloss.Width(line))
+	}
+}
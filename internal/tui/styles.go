@@ -1,79 +1,232 @@
 package tui
 
 import (
+	"fmt"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Theme is the set of colors the TUI is drawn with.
+type Theme struct {
+	Name      string
+	Primary   lipgloss.Color // Titles, cursor, selection and prompts
+	Secondary lipgloss.Color // Dim text, help and detached sessions
+	Error     lipgloss.Color // Errors, bells and logging
+	Success   lipgloss.Color // Attached sessions
+	Warning   lipgloss.Color // Activity and watcher notices
+}
+
+// Built-in themes. Colors are ANSI 256 palette indexes, except for the
+// high-contrast theme which sticks to the 16 basic colors. An empty color
+// is the terminal's default foreground.
+var (
+	darkTheme = Theme{
+		Name:      "dark",
+		Primary:   "205", // Pink/magenta
+		Secondary: "240", // Gray
+		Error:     "196", // Red
+		Success:   "82",  // Green
+		Warning:   "214", // Orange
+	}
+	lightTheme = Theme{
+		Name:      "light",
+		Primary:   "162",
+		Secondary: "243",
+		Error:     "160",
+		Success:   "28",
+		Warning:   "166",
+	}
+	highContrastTheme = Theme{
+		Name:      "high-contrast",
+		Primary:   "14",
+		Secondary: "", // Readable on light and dark backgrounds
+		Error:     "9",
+		Success:   "10",
+		Warning:   "11",
+	}
+)
+
+// builtinThemes are always available by name.
+var builtinThemes = []Theme{darkTheme, lightTheme, highContrastTheme}
+
 var (
 	// Colors - respect NO_COLOR env var
 	noColor = os.Getenv("NO_COLOR") != ""
 
-	// Primary colors
-	primaryColor   = lipgloss.Color("205") // Pink/magenta
-	secondaryColor = lipgloss.Color("240") // Gray
-	errorColor     = lipgloss.Color("196") // Red
-	successColor   = lipgloss.Color("82")  // Green
-
 	// Title style
-	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(primaryColor).
-			MarginBottom(1)
+	TitleStyle lipgloss.Style
 
-	// List item styles
-	ItemStyle = lipgloss.NewStyle().
-			PaddingLeft(2)
+	// Header style for the fixed main-screen layout (no margin)
+	HeaderStyle lipgloss.Style
 
-	SelectedItemStyle = lipgloss.NewStyle().
-				Foreground(primaryColor).
-				Bold(true)
+	// List item styles
+	ItemStyle         lipgloss.Style
+	SelectedItemStyle lipgloss.Style
 
 	// Cursor style
-	CursorStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
+	CursorStyle lipgloss.Style
 
 	// Session status styles
-	AttachedStyle = lipgloss.NewStyle().
-			Foreground(successColor)
+	AttachedStyle lipgloss.Style
+	DetachedStyle lipgloss.Style
+
+	// Activity badge styles
+	BellStyle     lipgloss.Style
+	ActivityStyle lipgloss.Style
 
-	DetachedStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor)
+	// Logging indicator style
+	LoggingStyle lipgloss.Style
 
 	// Help/footer style
-	HelpStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			MarginTop(1)
+	HelpStyle lipgloss.Style
+
+	// Footer style for the help bar in the fixed layout (no margin)
+	FooterStyle lipgloss.Style
 
 	// Prompt style for confirmations and inputs
-	PromptStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
+	PromptStyle lipgloss.Style
 
 	// Error style
-	ErrorStyle = lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true)
+	ErrorStyle lipgloss.Style
+
+	// Notice style for watcher events
+	NoticeStyle lipgloss.Style
+
+	// Dialog style for modal overlays
+	DialogStyle lipgloss.Style
 
 	// Dim style for secondary info
-	DimStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor)
+	DimStyle lipgloss.Style
 )
 
 func init() {
-	if noColor {
-		// Reset all colors for NO_COLOR compliance
-		TitleStyle = lipgloss.NewStyle().Bold(true).MarginBottom(1)
-		ItemStyle = lipgloss.NewStyle().PaddingLeft(2)
-		SelectedItemStyle = lipgloss.NewStyle().Bold(true)
-		CursorStyle = lipgloss.NewStyle().Bold(true)
-		AttachedStyle = lipgloss.NewStyle()
-		DetachedStyle = lipgloss.NewStyle()
-		HelpStyle = lipgloss.NewStyle().MarginTop(1)
-		PromptStyle = lipgloss.NewStyle().Bold(true)
-		ErrorStyle = lipgloss.NewStyle().Bold(true)
-		DimStyle = lipgloss.NewStyle()
+	applyTheme(darkTheme)
+}
+
+// applyTheme sets every style from a theme. With NO_COLOR set, the colors
+// are left out and only bold text and borders remain.
+func applyTheme(t Theme) {
+	color := func(s lipgloss.Style, c lipgloss.Color) lipgloss.Style {
+		if noColor || c == "" {
+			return s
+		}
+		return s.Foreground(c)
+	}
+
+	TitleStyle = color(lipgloss.NewStyle().Bold(true).MarginBottom(1), t.Primary)
+	HeaderStyle = color(lipgloss.NewStyle().Bold(true), t.Primary)
+	ItemStyle = lipgloss.NewStyle().PaddingLeft(2)
+	SelectedItemStyle = color(lipgloss.NewStyle().Bold(true), t.Primary)
+	CursorStyle = color(lipgloss.NewStyle().Bold(true), t.Primary)
+	AttachedStyle = color(lipgloss.NewStyle(), t.Success)
+	DetachedStyle = color(lipgloss.NewStyle(), t.Secondary)
+	BellStyle = color(lipgloss.NewStyle().Bold(true), t.Error)
+	ActivityStyle = color(lipgloss.NewStyle().Bold(true), t.Warning)
+	LoggingStyle = color(lipgloss.NewStyle(), t.Error)
+	HelpStyle = color(lipgloss.NewStyle().MarginTop(1), t.Secondary)
+	FooterStyle = color(lipgloss.NewStyle(), t.Secondary)
+	PromptStyle = color(lipgloss.NewStyle().Bold(true), t.Primary)
+	ErrorStyle = color(lipgloss.NewStyle().Bold(true), t.Error)
+	NoticeStyle = color(lipgloss.NewStyle(), t.Warning)
+	DimStyle = color(lipgloss.NewStyle(), t.Secondary)
+
+	DialogStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	if !noColor && t.Primary != "" {
+		DialogStyle = DialogStyle.BorderForeground(t.Primary)
+	}
+}
+
+// colorPattern matches the colors a theme accepts: a 256 palette index or
+// a hex RGB value.
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[0-9]{1,3})$`)
+
+// setColor sets the color of one role (primary, secondary, error,
+// success or warning).
+func (t *Theme) setColor(role, value string) error {
+	var c *lipgloss.Color
+	switch role {
+	case "primary":
+		c = &t.Primary
+	case "secondary":
+		c = &t.Secondary
+	case "error":
+		c = &t.Error
+	case "success":
+		c = &t.Success
+	case "warning":
+		c = &t.Warning
+	default:
+		return fmt.Errorf("%s: unknown color (available: primary, secondary, error, success, warning)", role)
+	}
+	n, err := strconv.Atoi(value)
+	if !colorPattern.MatchString(value) || err == nil && n > 255 {
+		return fmt.Errorf("%s: '%s' is not a color (use 0-255 or #rrggbb)", role, value)
+	}
+	*c = lipgloss.Color(value)
+	return nil
+}
+
+// findTheme returns the built-in theme called name.
+func findTheme(name string) (Theme, bool) {
+	for _, t := range builtinThemes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// detectTheme picks the dark or light theme from the terminal background.
+// It queries the terminal, so call it before the program takes over input.
+// Without colors there is nothing to pick and no query.
+func detectTheme() Theme {
+	if noColor || lipgloss.HasDarkBackground() {
+		return darkTheme
+	}
+	return lightTheme
+}
+
+// resolveTheme builds the theme called name: "auto", a built-in theme, or
+// one defined in the config file as role -> color, based on the theme its
+// "base" names (default auto).
+func resolveTheme(name string, custom map[string]map[string]string) (Theme, error) {
+	if name == "" || name == "auto" {
+		return detectTheme(), nil
+	}
+	colors, ok := custom[name]
+	if !ok {
+		if t, ok := findTheme(name); ok {
+			return t, nil
+		}
+		names := []string{"auto"}
+		for _, t := range builtinThemes {
+			names = append(names, t.Name)
+		}
+		names = append(names, slices.Sorted(maps.Keys(custom))...)
+		return Theme{}, fmt.Errorf("unknown theme '%s' (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	base := detectTheme()
+	if b, ok := colors["base"]; ok && b != "auto" {
+		if base, ok = findTheme(b); !ok {
+			return Theme{}, fmt.Errorf("theme.%s.base: unknown built-in theme '%s'", name, b)
+		}
+	}
+	t := base
+	t.Name = name
+	for role, value := range colors {
+		if role == "base" {
+			continue
+		}
+		if err := t.setColor(role, value); err != nil {
+			return Theme{}, fmt.Errorf("theme.%s.%w", name, err)
+		}
 	}
+	return t, nil
 }
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSetColor(t *testing.T) {
+	tests := []struct {
+		role, value string
+		wantErr     string
+	}{
+		{"primary", "205", ""},
+		{"secondary", "0", ""},
+		{"error", "255", ""},
+		{"success", "#0f0", ""},
+		{"warning", "#FFaa00", ""},
+		{"primary", "256", "not a color"},
+		{"primary", "-1", "not a color"},
+		{"primary", "red", "not a color"},
+		{"primary", "#12345", "not a color"},
+		{"primary", "", "not a color"},
+		{"accent", "1", "unknown color"},
+	}
+	for _, tt := range tests {
+		theme := darkTheme
+		err := theme.setColor(tt.role, tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("setColor(%q, %q) = %v, want %q", tt.role, tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("setColor(%q, %q): %v", tt.role, tt.value, err)
+			continue
+		}
+		got := map[string]lipgloss.Color{
+			"primary": theme.Primary, "secondary": theme.Secondary, "error": theme.Error,
+			"success": theme.Success, "warning": theme.Warning,
+		}[tt.role]
+		if got != lipgloss.Color(tt.value) {
+			t.Errorf("setColor(%q, %q) set %q", tt.role, tt.value, got)
+		}
+	}
+}
+
+func TestResolveTheme(t *testing.T) {
+	// Without colors "auto" picks the dark theme without asking the terminal
+	defer func(v bool) { noColor = v }(noColor)
+	noColor = true
+
+	custom := map[string]map[string]string{
+		"mine":    {"base": "light", "primary": "#00afff"},
+		"auto":    {"secondary": "245"},
+		"broken":  {"primary": "pink"},
+		"nobase":  {"base": "sepia"},
+		"zebra":   {},
+		"another": {"base": "high-contrast"},
+	}
+	tests := []struct {
+		name    string
+		want    Theme
+		wantErr string
+	}{
+		{name: "", want: darkTheme},
+		{name: "auto", want: darkTheme},
+		{name: "light", want: lightTheme},
+		{name: "high-contrast", want: highContrastTheme},
+		{name: "mine", want: func() Theme {
+			t := lightTheme
+			t.Name, t.Primary = "mine", "#00afff"
+			return t
+		}()},
+		{name: "zebra", want: func() Theme {
+			t := darkTheme
+			t.Name = "zebra"
+			return t
+		}()},
+		{name: "another", want: func() Theme {
+			t := highContrastTheme
+			t.Name = "another"
+			return t
+		}()},
+		{name: "broken", wantErr: "theme.broken.primary: 'pink' is not a color (use 0-255 or #rrggbb)"},
+		{name: "nobase", wantErr: "theme.nobase.base: unknown built-in theme 'sepia'"},
+		{name: "solarized", wantErr: "unknown theme 'solarized' (available: auto, dark, light, high-contrast, another, auto, broken, mine, nobase, zebra)"},
+	}
+	for _, tt := range tests {
+		got, err := resolveTheme(tt.name, custom)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("resolveTheme(%q) error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("resolveTheme(%q): %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveTheme(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
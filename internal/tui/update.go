@@ -2,18 +2,34 @@ package tui
 
 import (
 	"byoman/internal/byobu"
+	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // Update handles messages and updates the model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	if next, ok := model.(Model); ok {
+		// Keep the cursor inside the list viewport after every change
+		next.syncScroll()
+		return next, cmd
+	}
+	return model, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.WindowSizeMsg:
-		m.list.SetSize(msg.Width, msg.Height-4)
+		m.width, m.height = msg.Width, msg.Height
+		m.list.SetSize(msg.Width, max(msg.Height-4, 0))
+		m.logViewport.Width, m.logViewport.Height = msg.Width, max(msg.Height-4, 0)
 		return m, nil
 
 	case tickMsg:
@@ -21,22 +37,93 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if item, ok := m.list.SelectedItem().(sessionItem); ok {
 			m.selectedName = item.session.Name
 		}
-		return m, tea.Batch(loadSessions(m.client), tickCmd())
+		cmds := []tea.Cmd{m.loadSessions(), tickCmd(m.config.Refresh)}
+		if m.state == StateProcesses {
+			cmds = append(cmds, loadProcessTree(m.currentPane()))
+		}
+		if m.state == StateClients {
+			cmds = append(cmds, loadClients(m.client, m.selectedName))
+		}
+		return m, tea.Batch(cmds...)
+
+	case panesLoadedMsg:
+		return m.handlePanesLoaded(msg)
+
+	case processTreeMsg:
+		return m.handleProcessTree(msg)
+
+	case broadcastTargetsMsg:
+		return m.handleBroadcastTargets(msg)
+
+	case broadcastDoneMsg:
+		return m.handleBroadcastDone(msg)
+
+	case logFilesMsg:
+		return m.handleLogFiles(msg)
+
+	case logContentMsg:
+		return m.handleLogContent(msg)
+
+	case sessionOptionsMsg:
+		return m.handleSessionOptions(msg)
+
+	case clientsLoadedMsg:
+		return m.handleClientsLoaded(msg)
+
+	case shareInfoLoadedMsg:
+		return m.handleShareInfoLoaded(msg)
+
+	case userCommandMsg:
+		return m.handleUserCommandDone(msg)
+
+	case nameSuggestedMsg:
+		return m.handleNameSuggested(msg)
 
 	case sessionsLoadedMsg:
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
+		m.usage = msg.usage
+		if msg.logging != nil {
+			m.logging = msg.logging
+			m.checkLogging = false
+		}
+		if msg.panesErr != nil {
+			m.err = msg.panesErr
+		}
+		if msg.usageErr != nil {
+			m.err = msg.usageErr
+			m.showUsage = false
+			m.sortMode = SortDefault
+		}
 		m.updateSessionsPreserveSelection(msg.sessions)
-		return m, nil
+		m.recordAlerts(msg.events)
+		if len(msg.events) > 0 {
+			m.notice = msg.events[len(msg.events)-1].String()
+		}
+		return m, notify(m.notifiers, msg.events)
 
 	case sessionActionMsg:
+		if msg.dialog != nil && msg.dialog == m.dialog {
+			return m.handleDialogResult(msg)
+		}
+		if msg.logging {
+			m.checkLogging = true
+		}
+		if msg.warning != "" {
+			m.notice = msg.warning
+		}
 		if msg.err != nil {
 			m.err = msg.err
+		} else if msg.attach != "" {
+			m.selectedSession = msg.attach
+			m.attachOptions.Throwaway = msg.throwaway
+			m.quitting = true
+			return m, tea.Quit
 		}
 		// Refresh after action
-		return m, loadSessions(m.client)
+		return m, m.loadSessions()
 	}
 
 	var cmd tea.Cmd
@@ -48,6 +135,12 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any error on keypress
 	m.err = nil
 
+	// Any key closes the full help
+	if m.showHelp {
+		m.showHelp = false
+		return m, nil
+	}
+
 	// Handle based on current state
 	switch m.state {
 	case StateConfirmKill:
@@ -56,106 +149,303 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleNewSession(msg)
 	case StateRenameSession:
 		return m.handleRenameSession(msg)
+	case StatePanes:
+		return m.handlePanesState(msg)
+	case StateProcesses:
+		return m.handleProcessesState(msg)
+	case StateConfirmSignal:
+		return m.handleConfirmSignal(msg)
+	case StateSendKeys:
+		return m.handleSendKeys(msg)
+	case StateBroadcastFilter:
+		return m.handleBroadcastFilter(msg)
+	case StateBroadcastCommand:
+		return m.handleBroadcastCommand(msg)
+	case StateConfirmBroadcast:
+		return m.handleConfirmBroadcast(msg)
+	case StateBroadcastReport:
+		return m.handleBroadcastReport(msg)
+	case StateAlertRules:
+		return m.handleAlertRulesState(msg)
+	case StateAddRule:
+		return m.handleAddRule(msg)
+	case StateLogs:
+		return m.handleLogsState(msg)
+	case StateLogView:
+		return m.handleLogViewState(msg)
+	case StateOptions:
+		return m.handleOptionsState(msg)
+	case StateWindows:
+		return m.handleWindowsState(msg)
+	case StateNewWindow:
+		return m.handleNewWindow(msg)
+	case StateRenameWindow:
+		return m.handleRenameWindow(msg)
+	case StateKillWindow:
+		return m.handleKillWindow(msg)
+	case StateMoveWindow, StateLinkWindow:
+		return m.handleWindowTarget(msg)
+	case StateSplitPane:
+		return m.handleSplitPane(msg)
+	case StateKillPane:
+		return m.handleKillPane(msg)
+	case StateJoinPane:
+		return m.handleJoinPane(msg)
+	case StateSelectLayout:
+		return m.handleSelectLayout(msg)
+	case StateClients:
+		return m.handleClientsState(msg)
+	case StateShare:
+		return m.handleShareState(msg)
+	case StatePalette:
+		return m.handlePaletteState(msg)
 	default:
 		return m.handleListState(msg)
 	}
 }
 
 func (m Model) handleListState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
+	if action, ok := m.keys.actionFor(msg); ok {
+		return m.runListAction(action)
+	}
+	return m, nil
+}
+
+// runListAction runs an action of the session list by its name in the
+// config file. Keys, help bar clicks and the command palette all end up
+// here.
+func (m Model) runListAction(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "up":
+		m.list.CursorUp()
+		return m, nil
+
+	case "down":
+		m.list.CursorDown()
+		return m, nil
+
+	case "palette":
+		return m.openPalette()
+
+	case "help":
+		m.showHelp = true
+		return m, nil
+
+	case "quit":
 		m.quitting = true
 		return m, tea.Quit
 
-	case "enter":
+	case "attach":
 		if session, ok := m.currentSession(); ok {
 			m.selectedSession = session.Name
 			m.quitting = true
 			return m, tea.Quit
 		}
 
-	case "n":
-		m.state = StateNewSession
-		m.textInput.Reset()
-		m.textInput.Placeholder = "session name"
-		m.textInput.Focus()
-		return m, nil
+	case "new":
+		return m.openNewSession()
 
-	case "r":
+	case "rename":
 		if session, ok := m.currentSession(); ok {
+			name := textField("New name", "session name", session.Name)
+			name.input.CharLimit = byobu.MaxSessionNameLength
 			m.state = StateRenameSession
-			m.textInput.Reset()
-			m.textInput.SetValue(session.Name)
-			m.textInput.Focus()
+			m.confirmTarget = session.Name
+			m.dialog = newDialog(fmt.Sprintf("Rename '%s'", session.Name), "[Enter] rename  [Esc] cancel", name)
 			return m, nil
 		}
 
-	case "k":
+	case "kill":
 		if session, ok := m.currentSession(); ok {
 			m.state = StateConfirmKill
 			m.confirmTarget = session.Name
+			m.dialog = newDialog("Kill session", "[y/Enter] kill  [n/Esc] cancel")
+			m.dialog.body = []string{fmt.Sprintf("Kill '%s' and everything running in it?", session.Name)}
 			return m, nil
 		}
-	}
 
-	var cmd tea.Cmd
-	m.list, cmd = m.list.Update(msg)
-	return m, cmd
-}
+	case "attach-exclusive":
+		if session, ok := m.currentSession(); ok {
+			m.selectedSession = session.Name
+			m.attachOptions.DetachOthers = true
+			m.quitting = true
+			return m, tea.Quit
+		}
 
-func (m Model) handleConfirmKill(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
-		name := m.confirmTarget
-		m.state = StateList
-		m.confirmTarget = ""
-		return m, killSession(m.client, name)
-	default:
-		// Any other key cancels
-		m.state = StateList
-		m.confirmTarget = ""
+	case "attach-read-only":
+		if session, ok := m.currentSession(); ok {
+			m.selectedSession = session.Name
+			m.attachOptions.ReadOnly = true
+			m.quitting = true
+			return m, tea.Quit
+		}
+
+	case "share":
+		if session, ok := m.currentSession(); ok {
+			return m, loadShareInfo(m.client, session.Name)
+		}
+
+	case "view":
+		if session, ok := m.currentSession(); ok {
+			name := byobu.UniqueSessionName(session.Name+"-view", m.sessionNames())
+			return m, newView(m.client, session.Name, name)
+		}
+
+	case "clients":
+		if session, ok := m.currentSession(); ok {
+			m.clientCursor = 0
+			return m, loadClients(m.client, session.Name)
+		}
+
+	case "windows":
+		if _, ok := m.currentSession(); ok {
+			m.state = StateWindows
+			m.windowCursor = 0
+			return m, nil
+		}
+
+	case "panes":
+		if session, ok := m.currentSession(); ok {
+			return m, loadPanes(m.client, session.Name)
+		}
+
+	case "exec":
+		if session, ok := m.currentSession(); ok {
+			return m.promptSendKeys(session.Name, fmt.Sprintf("session '%s'", session.Name))
+		}
+
+	case "mark":
+		if session, ok := m.currentSession(); ok {
+			if m.marked[session.Name] {
+				delete(m.marked, session.Name)
+			} else {
+				m.marked[session.Name] = true
+			}
+			return m, nil
+		}
+
+	case "broadcast":
+		return m.startBroadcast()
+
+	case "status-bar":
+		if session, ok := m.currentSession(); ok {
+			targets := m.markedSessions()
+			if len(targets) == 0 {
+				targets = []string{session.Name}
+			}
+			return m, setStatusBar(m.client, targets, !session.HasMinimalStatusBar())
+		}
+
+	case "options":
+		if session, ok := m.currentSession(); ok {
+			m.profileCursor = 0
+			return m, loadSessionOptions(m.client, session.Name)
+		}
+
+	case "watch":
+		return m.toggleWatch()
+
+	case "alerts":
+		m.state = StateAlertRules
+		m.ruleCursor = 0
 		return m, nil
+
+	case "clear-alerts":
+		if session, ok := m.currentSession(); ok {
+			delete(m.alerts, session.Name)
+			m.notice = ""
+			return m, nil
+		}
+
+	case "log":
+		if session, ok := m.currentSession(); ok {
+			name := session.Name
+			return m, toggleLogging(m.client, func(p byobu.Pane) bool { return p.SessionName == name })
+		}
+
+	case "logs":
+		return m, loadLogFiles()
+
+	case "usage":
+		m.showUsage = !m.showUsage
+		return m, m.loadSessions()
+
+	case "sort":
+		m.sortMode = (m.sortMode + 1) % (SortActivity + 1)
+		m.resort()
+		return m, m.loadSessions()
 	}
+	return m, nil
 }
 
-func (m Model) handleNewSession(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (m Model) handleRenameSession(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "enter":
-		name := m.textInput.Value()
-		m.state = StateList
-		m.textInput.Blur()
-		return m, newSession(m.client, name)
 	case "esc":
-		m.state = StateList
-		m.textInput.Blur()
+		m.closeDialog()
 		return m, nil
+	case "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		newName := m.dialog.value(0)
+		if newName == m.confirmTarget {
+			m.closeDialog()
+			return m, nil
+		}
+		if problem := m.nameProblem(newName, m.confirmTarget); problem != "" {
+			m.dialog.fail(problem)
+			return m, nil
+		}
+		m.selectedName = newName
+		return m, m.dialog.submit(renameSession(m.client, m.confirmTarget, newName))
 	}
 
-	var cmd tea.Cmd
-	m.textInput, cmd = m.textInput.Update(msg)
+	cmd := m.dialog.update(msg)
+	m.dialog.message = m.nameProblem(m.dialog.value(0), m.confirmTarget)
 	return m, cmd
 }
 
-func (m Model) handleRenameSession(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (m Model) handleConfirmKill(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "enter":
-		if session, ok := m.currentSession(); ok {
-			newName := m.textInput.Value()
-			m.state = StateList
-			m.textInput.Blur()
-			return m, renameSession(m.client, session.Name, newName)
+	case "y", "Y", "enter":
+		if m.dialog.pending {
+			return m, nil
 		}
-		m.state = StateList
-		return m, nil
+		return m, m.dialog.submit(killSession(m.client, m.confirmTarget))
+	case "n", "N", "esc":
+		m.closeDialog()
+	}
+	return m, nil
+}
+
+// promptSendKeys opens the send-keys prompt for target.
+func (m Model) promptSendKeys(target, label string) (tea.Model, tea.Cmd) {
+	m.sendTarget = target
+	m.sendTargetLabel = label
+	m.sendReturnState = m.state
+	m.state = StateSendKeys
+	m.dialog = newDialog("Send to "+label, "[Enter] send  [Esc] cancel",
+		textField("Command", "command", ""))
+	return m, nil
+}
+
+func (m Model) handleSendKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
 	case "esc":
-		m.state = StateList
-		m.textInput.Blur()
+		m.closeDialog()
 		return m, nil
+	case "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		keys := m.dialog.fields[0].input.Value()
+		if keys == "" {
+			m.dialog.fail("nothing to send")
+			return m, nil
+		}
+		return m, m.dialog.submit(sendKeys(m.client, m.sendTarget, keys))
 	}
-
-	var cmd tea.Cmd
-	m.textInput, cmd = m.textInput.Update(msg)
-	return m, cmd
+	return m, m.dialog.update(msg)
 }
 
 func killSession(client byobu.Client, name string) tea.Cmd {
@@ -165,19 +455,34 @@ func killSession(client byobu.Client, name string) tea.Cmd {
 	}
 }
 
-func newSession(client byobu.Client, name string) tea.Cmd {
+// setStatusBar applies the minimal status bar to names, or restores the
+// default one.
+func setStatusBar(client byobu.Client, names []string, minimal bool) tea.Cmd {
+	return func() tea.Msg {
+		for _, name := range names {
+			var err error
+			if minimal {
+				err = client.ConfigureMinimalStatusBar(name)
+			} else {
+				err = client.RestoreStatusBar(name)
+			}
+			if err != nil {
+				return sessionActionMsg{err: err}
+			}
+		}
+		return sessionActionMsg{}
+	}
+}
+
+// newView creates a session grouped with target and attaches to it as a
+// throwaway, which tmux destroys when its last client detaches.
+func newView(client byobu.Client, target, name string) tea.Cmd {
 	return func() tea.Msg {
-		err := client.NewSession(name)
+		err := client.NewSession(name, byobu.NewSessionOptions{GroupWith: target})
 		if err != nil {
 			return sessionActionMsg{err: err}
 		}
-		// Configure minimal status bar for the new session
-		// Log warning but don't fail if status bar config fails
-		if sbErr := client.ConfigureMinimalStatusBar(name); sbErr != nil {
-			// Status bar config is best-effort, don't fail the session creation
-			_ = sbErr
-		}
-		return sessionActionMsg{err: nil}
+		return sessionActionMsg{attach: name, throwaway: true}
 	}
 }
 
@@ -187,3 +492,10 @@ func renameSession(client byobu.Client, oldName, newName string) tea.Cmd {
 		return sessionActionMsg{err: err}
 	}
 }
+
+func sendKeys(client byobu.Client, target, keys string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.SendKeys(target, keys, true)
+		return sessionActionMsg{err: err}
+	}
+}
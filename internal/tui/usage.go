@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"byoman/internal/proc"
+	"fmt"
+)
+
+// resourceUsage holds aggregated process usage at each level of the tree.
+type resourceUsage struct {
+	sessions map[string]proc.Usage // Session name -> usage
+	windows  map[string]proc.Usage // Window ID -> usage
+	panes    map[string]proc.Usage // Pane ID -> usage
+}
+
+// collectUsage samples the process tree of every pane and aggregates it
+// per pane, window and session. tmux lists a linked or grouped window once
+// for each session holding it, so a pane is counted once per window and
+// once per session: a shared window adds in full to every session showing
+// it.
+func collectUsage(panes []byobu.Pane, collector *proc.Collector) (resourceUsage, error) {
+	pids := make([]int, 0, len(panes))
+	for _, p := range panes {
+		pids = append(pids, p.PID)
+	}
+
+	byPID, err := collector.Sample(pids)
+	if err != nil {
+		return resourceUsage{}, err
+	}
+
+	u := resourceUsage{
+		sessions: make(map[string]proc.Usage),
+		windows:  make(map[string]proc.Usage),
+		panes:    make(map[string]proc.Usage, len(panes)),
+	}
+	inSession := make(map[[2]string]bool) // Session name, pane ID
+	for _, p := range panes {
+		pu := byPID[p.PID]
+		if _, seen := u.panes[p.ID]; !seen {
+			u.panes[p.ID] = pu
+
+			w := u.windows[p.WindowID]
+			w.Add(pu)
+			u.windows[p.WindowID] = w
+		}
+
+		key := [2]string{p.SessionName, p.ID}
+		if inSession[key] {
+			continue
+		}
+		inSession[key] = true
+		s := u.sessions[p.SessionName]
+		s.Add(pu)
+		u.sessions[p.SessionName] = s
+	}
+	return u, nil
+}
+
+// formatCPU renders a CPU percentage for the usage column.
+func formatCPU(cpu float64) string {
+	return fmt.Sprintf("%5.1f%%", cpu)
+}
+
+// formatBytes renders a byte count in human-readable binary units.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(n)/float64(div), "KMGTPE"[exp])
+}
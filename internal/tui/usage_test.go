@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"byoman/internal/proc"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestCollectUsageSharedWindows(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("usage is read from /proc")
+	}
+	pid := os.Getpid()
+	// Window @1 is linked into "b" and shown by "a" twice (as two
+	// window indexes); @2 belongs to "b" only.
+	panes := []byobu.Pane{
+		{SessionName: "a", WindowID: "@1", ID: "%1", PID: pid},
+		{SessionName: "a", WindowID: "@1", ID: "%1", PID: pid},
+		{SessionName: "b", WindowID: "@1", ID: "%1", PID: pid},
+		{SessionName: "b", WindowID: "@2", ID: "%2", PID: pid},
+	}
+	u, err := collectUsage(panes, proc.NewCollector())
+	if err != nil {
+		t.Fatal(err)
+	}
+	one := u.panes["%1"]
+	if one.RSS == 0 {
+		t.Fatalf("no usage sampled for pane %%1: %+v", one)
+	}
+	two := one
+	two.Add(u.panes["%2"])
+	tests := []struct {
+		name string
+		got  proc.Usage
+		want proc.Usage
+	}{
+		{"window @1", u.windows["@1"], one},
+		{"window @2", u.windows["@2"], u.panes["%2"]},
+		{"session a", u.sessions["a"], one},
+		{"session b", u.sessions["b"], two},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+}
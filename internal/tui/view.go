@@ -1,46 +1,64 @@
 package tui
 
 import (
+	"byoman/internal/byobu"
 	"fmt"
 	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
-// View renders the current state.
+// View renders the current state. Dialog states are drawn as a centered
+// box over the screen they were opened from.
 func (m Model) View() string {
 	if m.quitting {
 		return "" // Prevent terminal artifacts
 	}
+	if m.dialog != nil {
+		l := m.layout()
+		return overlay(m.renderScreen(m.backgroundState()), m.dialog.view(l.width, l.height), l.width, l.height)
+	}
+	if m.showHelp {
+		l := m.layout()
+		return overlay(m.renderScreen(m.state), m.renderFullHelp(), l.width, l.height)
+	}
+	return m.renderScreen(m.state)
+}
 
+// renderScreen renders the full-screen view for state.
+func (m Model) renderScreen(state ViewState) string {
 	var b strings.Builder
 
-	switch m.state {
-	case StateConfirmKill:
-		b.WriteString(m.renderList())
-		b.WriteString("\n")
-		b.WriteString(PromptStyle.Render(fmt.Sprintf("Kill session '%s'? [y/N] ", m.confirmTarget)))
-
-	case StateNewSession:
-		b.WriteString(TitleStyle.Render("New session"))
-		b.WriteString("\n\n")
-		b.WriteString(fmt.Sprintf("Session name: %s", m.textInput.View()))
-		b.WriteString("\n\n")
-		b.WriteString(HelpStyle.Render("[Enter] create  [Esc] cancel"))
-
-	case StateRenameSession:
-		if session, ok := m.currentSession(); ok {
-			b.WriteString(TitleStyle.Render(fmt.Sprintf("Rename '%s'", session.Name)))
-		} else {
-			b.WriteString(TitleStyle.Render("Rename session"))
-		}
-		b.WriteString("\n\n")
-		b.WriteString(fmt.Sprintf("New name: %s", m.textInput.View()))
-		b.WriteString("\n\n")
-		b.WriteString(HelpStyle.Render("[Enter] rename  [Esc] cancel"))
+	switch state {
+	case StateList:
+		// The main screen has its own status line for errors and notices
+		return m.renderMain()
+
+	case StateAlertRules:
+		b.WriteString(m.renderAlertRules())
+
+	case StateOptions:
+		b.WriteString(m.renderOptions())
+
+	case StateLogs:
+		b.WriteString(m.renderLogs())
+
+	case StateLogView:
+		b.WriteString(m.renderLogView())
+
+	case StatePanes:
+		b.WriteString(m.renderPanes())
+
+	case StateProcesses:
+		b.WriteString(m.renderProcesses())
+
+	case StateWindows:
+		b.WriteString(m.renderWindowList())
+
+	case StateClients:
+		b.WriteString(m.renderClients())
 
-	default:
-		b.WriteString(m.renderList())
-		b.WriteString("\n")
-		b.WriteString(m.renderHelp())
 	}
 
 	// Show error if any
@@ -52,57 +70,193 @@ func (m Model) View() string {
 	return b.String()
 }
 
-func (m Model) renderList() string {
-	if len(m.sessions) == 0 {
-		return TitleStyle.Render("byobu sessions") + "\n\n" +
-			DimStyle.Render("No byobu sessions. Press 'n' to create one.")
-	}
+// maxNameWidth caps the session name column in the list.
+const maxNameWidth = 24
 
-	var b strings.Builder
-	b.WriteString(TitleStyle.Render("byobu sessions"))
-	b.WriteString("\n\n")
+// renderSession renders session i as one row, or as a card when compact.
+// Columns are padded before styling so the selected row lines up with the
+// others.
+func (m Model) renderSession(i int) []string {
+	session := m.sessions[i]
+	selected := i == m.list.Index()
 
-	for i, session := range m.sessions {
-		cursor := "  "
-		if i == m.list.Index() {
-			cursor = CursorStyle.Render("> ")
-		}
+	cursor := "  "
+	if selected {
+		cursor = CursorStyle.Render("> ")
+	}
+	mark := "  "
+	if m.marked[session.Name] {
+		mark = PromptStyle.Render("* ")
+	}
 
-		name := session.Name
-		if i == m.list.Index() {
-			name = SelectedItemStyle.Render(name)
-		}
+	if m.compact() {
+		return m.renderCard(session, cursor+mark, selected)
+	}
 
-		// Format: cursor name    windows  (status)  commands
+	name := ansi.Truncate(session.Name, m.nameWidth(), "…")
+	name += strings.Repeat(" ", m.nameWidth()-ansi.StringWidth(name))
+	if selected {
+		name = SelectedItemStyle.Render(name)
+	}
+
+	// Format: cursor name    windows  (status)  usage  commands
+	line := cursor + mark + name
+	if m.config.ShowColumn("windows") {
 		windowWord := "windows"
 		if session.WindowCount == 1 {
 			windowWord = "window"
 		}
-		windows := DimStyle.Render(fmt.Sprintf("%d %s", session.WindowCount, windowWord))
+		line += "  " + DimStyle.Render(fmt.Sprintf("%-10s", fmt.Sprintf("%d %s", session.WindowCount, windowWord)))
+	}
+	if m.config.ShowColumn("status") {
+		status := m.renderStatus(session)
+		line += "  " + status + strings.Repeat(" ", m.statusWidth()-lipgloss.Width(status))
+	}
+	if m.showUsage {
+		u := m.usage.sessions[session.Name]
+		line += "  " + DimStyle.Render(fmt.Sprintf("%s %7s", formatCPU(u.CPU), formatBytes(u.RSS)))
+	}
+	if m.config.ShowColumn("commands") && len(session.Commands) > 0 {
+		line += "  " + DimStyle.Render(strings.Join(session.Commands, ", "))
+	}
+	return []string{line}
+}
 
-		var status string
-		if session.Attached > 0 {
-			status = AttachedStyle.Render("(attached)")
-		} else {
-			status = DetachedStyle.Render("(detached)")
-		}
+// renderCard renders a session as a two-line card plus a spacer line:
+//
+//	> name  (detached) #
+//	    2w · vim, zsh
+func (m Model) renderCard(session byobu.Session, prefix string, selected bool) []string {
+	name := session.Name
+	if selected {
+		name = SelectedItemStyle.Render(name)
+	}
+	first := prefix + name
+	if m.config.ShowColumn("status") {
+		first += "  " + m.renderStatus(session)
+	}
 
-		var commands string
-		if len(session.Commands) > 0 {
-			commands = DimStyle.Render(strings.Join(session.Commands, ", "))
-		}
+	var details []string
+	if m.config.ShowColumn("windows") {
+		details = append(details, fmt.Sprintf("%dw", session.WindowCount))
+	}
+	if m.showUsage {
+		u := m.usage.sessions[session.Name]
+		details = append(details, strings.TrimSpace(formatCPU(u.CPU)), formatBytes(u.RSS))
+	}
+	if m.config.ShowColumn("commands") && len(session.Commands) > 0 {
+		details = append(details, strings.Join(session.Commands, ", "))
+	}
+	second := "    " + DimStyle.Render(strings.Join(details, " · "))
 
-		line := fmt.Sprintf("%s%-12s  %s  %s", cursor, name, windows, status)
-		if commands != "" {
-			line += "  " + commands
+	return []string{first, second, ""}
+}
+
+// nameWidth returns the width of the name column.
+func (m Model) nameWidth() int {
+	width := 12
+	for _, s := range m.sessions {
+		width = max(width, ansi.StringWidth(s.Name))
+	}
+	return min(width, maxNameWidth)
+}
+
+// statusWidth returns the width of the widest status column.
+func (m Model) statusWidth() int {
+	width := 0
+	for _, s := range m.sessions {
+		width = max(width, lipgloss.Width(m.renderStatus(s)))
+	}
+	return width
+}
+
+// renderWindows renders a session's windows tmux-style, e.g. "0:bash* 1:vim#".
+func renderWindows(session byobu.Session) string {
+	parts := make([]string, 0, len(session.Windows))
+	for _, w := range session.Windows {
+		label := fmt.Sprintf("%d:%s", w.Index, w.Name)
+		if w.Active {
+			label += "*"
 		}
-		b.WriteString(line)
-		b.WriteString("\n")
+		parts = append(parts, label+renderWindowFlags(w))
 	}
+	return strings.Join(parts, " ")
+}
 
-	return b.String()
+// renderWindowFlags returns a window's tmux-style flags: "!" bell,
+// "#" activity, "~" silence.
+func renderWindowFlags(w byobu.Window) string {
+	var flags string
+	if w.BellFlag {
+		flags += BellStyle.Render("!")
+	}
+	if w.ActivityFlag {
+		flags += ActivityStyle.Render("#")
+	}
+	if w.SilenceFlag {
+		flags += ActivityStyle.Render("~")
+	}
+	return flags
+}
+
+// renderStatus returns the attached/detached status followed by badges.
+func (m Model) renderStatus(session byobu.Session) string {
+	var status string
+	if session.Attached > 0 {
+		status = AttachedStyle.Render("(attached)")
+	} else {
+		status = DetachedStyle.Render("(detached)")
+	}
+
+	if session.HasMinimalStatusBar() {
+		status += " " + DimStyle.Render("[min]")
+	}
+	if session.Throwaway {
+		status += " " + DimStyle.Render("[view]")
+	} else if session.Grouped {
+		status += " " + DimStyle.Render("[group]")
+	}
+	if m.logging[session.Name] {
+		status += " " + LoggingStyle.Render("[log]")
+	}
+	if n := m.alerts[session.Name]; n > 0 {
+		status += " " + BellStyle.Render(fmt.Sprintf("⚠%d", n))
+	}
+	if badges := renderBadges(session); badges != "" {
+		status += " " + badges
+	}
+	if m.sortMode == SortActivity && session.Activity.Unix() != 0 {
+		status += " " + DimStyle.Render(formatAgo(session.Activity))
+	}
+	return status
 }
 
+// helpSeparator returns the gap between help bar items.
+func (m Model) helpSeparator() string {
+	if m.compact() {
+		return " "
+	}
+	return "  "
+}
+
+// renderHelp renders the help bar from the key bindings.
 func (m Model) renderHelp() string {
-	return HelpStyle.Render("[n]ew  [r]ename  [k]ill  [enter]attach  [q]uit")
+	return m.helpModel().ShortHelpView(m.helpBindings())
+}
+
+// renderBadges returns tmux-style window flags for unseen events in any of
+// the session's windows: "!" bell, "#" activity, "~" silence. tmux clears a
+// flag once the window is viewed.
+func renderBadges(session byobu.Session) string {
+	var badges []string
+	if session.HasBell() {
+		badges = append(badges, BellStyle.Render("!"))
+	}
+	if session.HasActivity() {
+		badges = append(badges, ActivityStyle.Render("#"))
+	}
+	if session.HasSilence() {
+		badges = append(badges, ActivityStyle.Render("~"))
+	}
+	return strings.Join(badges, "")
 }
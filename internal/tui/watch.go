@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"byoman/internal/watch"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// desktopNotifiers returns the notifiers used inside the TUI, plus the
+// hook command from the config file if set. OSC escapes are not used here
+// since they would interleave with the TUI's own output.
+func desktopNotifiers(hook string) []watch.Notifier {
+	var notifiers []watch.Notifier
+	if _, err := exec.LookPath("notify-send"); err == nil {
+		notifiers = append(notifiers, watch.DesktopNotifier{})
+	}
+	if hook != "" {
+		notifiers = append(notifiers, watch.HookNotifier{Command: hook})
+	}
+	return notifiers
+}
+
+// notify delivers events in the background; delivery errors are ignored
+// since the event is also shown in the TUI.
+func notify(notifiers []watch.Notifier, events []watch.Event) tea.Cmd {
+	if len(notifiers) == 0 || len(events) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		for _, e := range events {
+			for _, n := range notifiers {
+				_ = n.Notify(e)
+			}
+		}
+		return nil
+	}
+}
+
+// toggleWatch starts or stops the attention watcher. A fresh tracker is
+// used each time so stale state doesn't produce events on resume.
+func (m Model) toggleWatch() (tea.Model, tea.Cmd) {
+	m.watching = !m.watching
+	if m.watching {
+		m.tracker = watch.NewTracker(watch.DefaultOptions())
+		m.notice = "Watching detached sessions for bells, silence and exits"
+	} else {
+		m.notice = ""
+	}
+	return m, m.loadSessions()
+}
@@ -0,0 +1,270 @@
+package tui
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// currentWindow returns the window under the cursor in the window list.
+func (m Model) currentWindow() (byobu.Window, bool) {
+	session, ok := m.currentSession()
+	if !ok || len(session.Windows) == 0 {
+		return byobu.Window{}, false
+	}
+	i := min(m.windowCursor, len(session.Windows)-1)
+	return session.Windows[i], true
+}
+
+// otherSessions returns the names of all sessions except name.
+func (m Model) otherSessions(name string) []string {
+	var names []string
+	for _, s := range m.sessions {
+		if s.Name != name {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+func (m Model) handleWindowsState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	session, _ := m.currentSession()
+	windows := session.Windows
+	m.windowCursor = min(m.windowCursor, max(len(windows)-1, 0))
+
+	switch msg.String() {
+	case "up", "k":
+		if m.windowCursor > 0 {
+			m.windowCursor--
+		}
+	case "down", "j":
+		if m.windowCursor < len(windows)-1 {
+			m.windowCursor++
+		}
+	case "n":
+		m.state = StateNewWindow
+		m.dialog = newDialog(fmt.Sprintf("New window in '%s'", session.Name), "[Tab] field  [Enter] create  [Esc] cancel",
+			textField("Name", "window name", ""),
+			textField("Directory", "start directory (default: session's)", ""),
+			textField("Command", "run instead of a shell", ""),
+		)
+	case "esc", "q":
+		m.state = StateList
+	}
+
+	w, ok := m.currentWindow()
+	if !ok {
+		return m, nil
+	}
+	label := fmt.Sprintf("%d:%s", w.Index, w.Name)
+
+	switch msg.String() {
+	case "r":
+		m.state = StateRenameWindow
+		m.dialog = newDialog(fmt.Sprintf("Rename window %s", label), "[Enter] rename  [Esc] cancel",
+			textField("New name", "window name", w.Name))
+	case "d", "x":
+		m.state = StateKillWindow
+		m.dialog = newDialog("Kill window", "[y/Enter] kill  [n/Esc] cancel")
+		m.dialog.body = []string{fmt.Sprintf("Kill window %s and everything running in it?", label)}
+		if w.Linked {
+			m.dialog.body = append(m.dialog.body, DimStyle.Render("It is also removed from the sessions it is linked into."))
+		}
+	case "m", "l":
+		others := m.otherSessions(session.Name)
+		if len(others) == 0 {
+			m.err = fmt.Errorf("no other session to put window %s in", label)
+			return m, nil
+		}
+		if msg.String() == "m" {
+			m.state = StateMoveWindow
+			m.dialog = newDialog(fmt.Sprintf("Move window %s", label), "[←/→] session  [Enter] move  [Esc] cancel",
+				choiceField("To session", others, ""))
+		} else {
+			m.state = StateLinkWindow
+			m.dialog = newDialog(fmt.Sprintf("Link window %s", label), "[←/→] session  [Enter] link  [Esc] cancel",
+				choiceField("Into session", others, ""))
+			m.dialog.body = []string{"The window will show in both sessions."}
+		}
+	case "[":
+		if m.windowCursor > 0 {
+			m.windowCursor--
+			m.swapLocalWindows(session.Name, m.windowCursor, m.windowCursor+1)
+			return m, swapWindow(m.client, w.ID, windows[m.windowCursor].ID)
+		}
+	case "]":
+		if m.windowCursor < len(windows)-1 {
+			m.windowCursor++
+			m.swapLocalWindows(session.Name, m.windowCursor-1, m.windowCursor)
+			return m, swapWindow(m.client, w.ID, windows[m.windowCursor].ID)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleNewWindow(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		if m.dialog.pending {
+			return m, nil
+		}
+		session, ok := m.currentSession()
+		if !ok {
+			m.closeDialog()
+			return m, nil
+		}
+		opts := byobu.NewWindowOptions{
+			Name:     m.dialog.value(0),
+			StartDir: expandHome(m.dialog.value(1)),
+			Command:  m.dialog.value(2),
+		}
+		if opts.StartDir != "" {
+			if info, err := os.Stat(opts.StartDir); err != nil || !info.IsDir() {
+				m.dialog.setFocus(1)
+				m.dialog.fail(fmt.Sprintf("'%s' is not a directory", opts.StartDir))
+				return m, nil
+			}
+		}
+		m.windowCursor = len(session.Windows)
+		return m, m.dialog.submit(runAction(func() error { return m.client.NewWindow(session.Name, opts) }))
+	}
+	return m, m.dialog.update(msg)
+}
+
+func (m Model) handleRenameWindow(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		w, ok := m.currentWindow()
+		if !ok || m.dialog.pending {
+			return m, nil
+		}
+		name := m.dialog.value(0)
+		if name == "" {
+			m.dialog.fail("window name cannot be empty")
+			return m, nil
+		}
+		return m, m.dialog.submit(runAction(func() error { return m.client.RenameWindow(w.ID, name) }))
+	}
+	return m, m.dialog.update(msg)
+}
+
+func (m Model) handleKillWindow(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		w, ok := m.currentWindow()
+		if !ok || m.dialog.pending {
+			return m, nil
+		}
+		return m, m.dialog.submit(runAction(func() error { return m.client.KillWindow(w.ID) }))
+	case "n", "N", "esc":
+		m.closeDialog()
+	}
+	return m, nil
+}
+
+// handleWindowTarget handles the move and link dialogs, which both pick a
+// destination session.
+func (m Model) handleWindowTarget(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDialog()
+		return m, nil
+	case "enter":
+		w, ok := m.currentWindow()
+		if !ok || m.dialog.pending {
+			return m, nil
+		}
+		dst := m.dialog.value(0)
+		if m.state == StateMoveWindow {
+			return m, m.dialog.submit(runAction(func() error { return m.client.MoveWindow(w.ID, dst) }))
+		}
+		return m, m.dialog.submit(runAction(func() error { return m.client.LinkWindow(w.ID, dst) }))
+	}
+	return m, m.dialog.update(msg)
+}
+
+// windowAction runs a window command and reports it as a session action,
+// which refreshes the list and with it the windows shown.
+func runAction(action func() error) tea.Cmd {
+	return func() tea.Msg {
+		return sessionActionMsg{err: action()}
+	}
+}
+
+// swapLocalWindows swaps windows i and j of a session in the list right
+// away, as swap-window will, so that quick presses build on each other
+// instead of swapping the same pair back before the reload.
+func (m *Model) swapLocalWindows(sessionName string, i, j int) {
+	sessions := append([]byobu.Session(nil), m.sessions...)
+	for k, s := range sessions {
+		if s.Name != sessionName {
+			continue
+		}
+		windows := append([]byobu.Window(nil), s.Windows...)
+		windows[i], windows[j] = windows[j], windows[i]
+		windows[i].Index, windows[j].Index = windows[j].Index, windows[i].Index
+		sessions[k].Windows = windows
+	}
+	m.selectedName = sessionName
+	m.updateSessionsPreserveSelection(sessions)
+}
+
+func swapWindow(client byobu.Client, target, other string) tea.Cmd {
+	return runAction(func() error { return client.SwapWindow(target, other) })
+}
+
+func (m Model) renderWindowList() string {
+	session, _ := m.currentSession()
+
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render(fmt.Sprintf("Windows in '%s'", session.Name)))
+	b.WriteString("\n\n")
+
+	if len(session.Windows) == 0 {
+		b.WriteString(DimStyle.Render("No windows. Press 'n' to create one."))
+		b.WriteString("\n")
+	}
+	cursorIndex := min(m.windowCursor, len(session.Windows)-1)
+	for i, w := range session.Windows {
+		cursor := "  "
+		label := fmt.Sprintf("%-20s", fmt.Sprintf("%d:%s", w.Index, w.Name))
+		if i == cursorIndex {
+			cursor = CursorStyle.Render("> ")
+			label = SelectedItemStyle.Render(label)
+		}
+
+		paneWord := "panes"
+		if w.PaneCount == 1 {
+			paneWord = "pane"
+		}
+		line := fmt.Sprintf("%s%s  %s", cursor, label, DimStyle.Render(fmt.Sprintf("%-8s", fmt.Sprintf("%d %s", w.PaneCount, paneWord))))
+		if w.Active {
+			line += "  " + AttachedStyle.Render("active")
+		}
+		if w.Linked {
+			line += "  " + DimStyle.Render("[linked]")
+		}
+		if flags := renderWindowFlags(w); flags != "" {
+			line += "  " + flags
+		}
+		if m.showUsage {
+			u := m.usage.windows[w.ID]
+			line += "  " + DimStyle.Render(fmt.Sprintf("%s %7s", formatCPU(u.CPU), formatBytes(u.RSS)))
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("[n]ew  [r]ename  [d]elete  [m]ove  [l]ink  [ / ] reorder  [esc]back"))
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package watch
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Notifier delivers an event to the user.
+type Notifier interface {
+	Notify(e Event) error
+}
+
+// OSCNotifier writes a terminal notification escape sequence.
+// Code 9 is understood by iTerm2, WezTerm and Windows Terminal;
+// 777 by rxvt-unicode, foot, Ghostty and VTE-based terminals.
+type OSCNotifier struct {
+	W    io.Writer
+	Code int // 9 or 777
+}
+
+// Notify writes the escape sequence for e.
+func (n OSCNotifier) Notify(e Event) error {
+	title := "byoman: " + e.Session
+	var seq string
+	if n.Code == 777 {
+		seq = fmt.Sprintf("\x1b]777;notify;%s;%s\x07", sanitizeOSC(title), sanitizeOSC(e.Message))
+	} else {
+		seq = fmt.Sprintf("\x1b]9;%s: %s\x07", sanitizeOSC(title), sanitizeOSC(e.Message))
+	}
+	_, err := io.WriteString(n.W, seq)
+	return err
+}
+
+// sanitizeOSC strips characters that would terminate the sequence early.
+func sanitizeOSC(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == ';' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// DesktopNotifier sends a desktop notification with notify-send.
+type DesktopNotifier struct{}
+
+// Notify runs notify-send for e.
+func (DesktopNotifier) Notify(e Event) error {
+	cmd := exec.Command("notify-send", "--app-name=byoman", "byoman: "+e.Session, e.Message)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notify-send: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// HookNotifier runs a user command via sh -c. The event is passed in the
+// BYOMAN_EVENT, BYOMAN_SESSION, BYOMAN_WINDOW and BYOMAN_MESSAGE variables.
+type HookNotifier struct {
+	Command string
+}
+
+// Notify runs the hook command for e.
+func (n HookNotifier) Notify(e Event) error {
+	cmd := exec.Command("sh", "-c", n.Command)
+	cmd.Env = append(os.Environ(),
+		"BYOMAN_EVENT="+e.Kind.String(),
+		"BYOMAN_SESSION="+e.Session,
+		"BYOMAN_WINDOW="+strconv.Itoa(e.Window),
+		"BYOMAN_MESSAGE="+e.Message,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("hook '%s': %v: %s", n.Command, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// NewNotifiers builds notifiers from a method name ("osc9", "osc777",
+// "notify-send", "auto" or "none") and an optional hook command.
+// OSC sequences are written to w.
+func NewNotifiers(method, hook string, w io.Writer) ([]Notifier, error) {
+	var notifiers []Notifier
+	switch method {
+	case "none", "":
+	case "osc9":
+		notifiers = append(notifiers, OSCNotifier{W: w, Code: 9})
+	case "osc777":
+		notifiers = append(notifiers, OSCNotifier{W: w, Code: 777})
+	case "notify-send":
+		notifiers = append(notifiers, DesktopNotifier{})
+	case "auto":
+		if _, err := exec.LookPath("notify-send"); err == nil && os.Getenv("SSH_CONNECTION") == "" {
+			notifiers = append(notifiers, DesktopNotifier{})
+		} else {
+			notifiers = append(notifiers, OSCNotifier{W: w, Code: 9})
+		}
+	default:
+		return nil, fmt.Errorf("unknown notification method '%s' (use osc9, osc777, notify-send, auto or none)", method)
+	}
+	if hook != "" {
+		notifiers = append(notifiers, HookNotifier{Command: hook})
+	}
+	return notifiers, nil
+}
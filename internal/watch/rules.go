@@ -0,0 +1,152 @@
+package watch
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// captureLines is how much scrollback is scanned per pane on each check.
+const captureLines = 200
+
+// Rule raises an alert when output in a session's panes matches Pattern.
+type Rule struct {
+	Session string         // Session name or glob; "*" matches every session
+	Pattern *regexp.Regexp // Regular expression matched per line
+}
+
+// ParseRule parses "SESSION:PATTERN", e.g. "api:panic:|FAIL".
+// Session names can't contain ':' so everything after the first one is the
+// pattern. An empty session matches every session.
+func ParseRule(s string) (Rule, error) {
+	session, pattern, ok := strings.Cut(s, ":")
+	if !ok || pattern == "" {
+		return Rule{}, fmt.Errorf("invalid rule '%s': expected SESSION:PATTERN", s)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid pattern in rule '%s': %w", s, err)
+	}
+	if session == "" {
+		session = "*"
+	}
+	return Rule{Session: session, Pattern: re}, nil
+}
+
+// String returns the rule in the form accepted by ParseRule.
+func (r Rule) String() string {
+	return r.Session + ":" + r.Pattern.String()
+}
+
+// MatchesSession reports whether the rule applies to the named session.
+func (r Rule) MatchesSession(name string) bool {
+	ok, _ := path.Match(r.Session, name)
+	return ok
+}
+
+// Matcher evaluates rules against captured pane output. A line only raises
+// an alert the first time it appears, not on every check while it stays
+// in the scrollback. Lines are told apart by their row in the pane's
+// history, so a repeated line alerts again. Once the history is full, rows
+// no longer move as output scrolls; a line then only counts as new if it
+// differs from the one previously in its row.
+type Matcher struct {
+	mu    sync.Mutex
+	rules []Rule
+	seen  map[string]map[matchedLine]bool // Pane ID + rule -> matched lines
+}
+
+// matchedLine is a line of output at a row of a pane's history.
+type matchedLine struct {
+	row  int
+	text string
+}
+
+// NewMatcher creates a matcher for rules.
+func NewMatcher(rules []Rule) *Matcher {
+	return &Matcher{rules: rules, seen: make(map[string]map[matchedLine]bool)}
+}
+
+// Rules returns a copy of the current rules.
+func (m *Matcher) Rules() []Rule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Rule(nil), m.rules...)
+}
+
+// AddRule appends a rule.
+func (m *Matcher) AddRule(r Rule) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules = append(m.rules, r)
+}
+
+// RemoveRule deletes the rule at index i.
+func (m *Matcher) RemoveRule(i int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i < 0 || i >= len(m.rules) {
+		return
+	}
+	m.rules = append(m.rules[:i], m.rules[i+1:]...)
+	m.seen = make(map[string]map[matchedLine]bool)
+}
+
+// Check captures every pane covered by a rule and returns an event per new
+// matching line. Panes that can't be captured are skipped.
+func (m *Matcher) Check(client byobu.Client, panes []byobu.Pane, now time.Time) []Event {
+	rules := m.Rules()
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var events []Event
+	seen := make(map[string]map[matchedLine]bool)
+	for _, p := range panes {
+		var applicable []Rule
+		for _, r := range rules {
+			if r.MatchesSession(p.SessionName) {
+				applicable = append(applicable, r)
+			}
+		}
+		if len(applicable) == 0 {
+			continue
+		}
+
+		capture, err := client.CapturePane(p.ID, captureLines)
+		if err != nil {
+			continue
+		}
+
+		for _, r := range applicable {
+			key := p.ID + "\x00" + r.String()
+			m.mu.Lock()
+			previous, checked := m.seen[key]
+			m.mu.Unlock()
+
+			matched := make(map[matchedLine]bool)
+			for i, line := range capture.Lines {
+				if !r.Pattern.MatchString(line) {
+					continue
+				}
+				ml := matchedLine{capture.First + i, line}
+				matched[ml] = true
+				// The first check only records what's already on screen
+				if checked && !previous[ml] {
+					events = append(events, Event{EventMatch, p.SessionName, p.WindowIndex,
+						fmt.Sprintf("/%s/ in pane %d.%d: %s", r.Pattern, p.WindowIndex, p.Index, strings.TrimSpace(line)), now})
+				}
+			}
+			seen[key] = matched
+		}
+	}
+
+	m.mu.Lock()
+	m.seen = seen
+	m.mu.Unlock()
+	return events
+}
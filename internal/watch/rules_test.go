@@ -0,0 +1,133 @@
+package watch
+
+import (
+	"byoman/internal/byobu"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		in      string
+		session string
+		pattern string
+		wantErr bool
+	}{
+		{"api:panic", "api", "panic", false},
+		{"api:panic:|FAIL", "api", "panic:|FAIL", false},
+		{":Traceback", "*", "Traceback", false},
+		{"web-*:error", "web-*", "error", false},
+		{"api", "", "", true},
+		{"api:", "", "", true},
+		{"api:(", "", "", true},
+	}
+	for _, tt := range tests {
+		r, err := ParseRule(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseRule(%q) = %v, want error", tt.in, r)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRule(%q): %v", tt.in, err)
+			continue
+		}
+		if r.Session != tt.session || r.Pattern.String() != tt.pattern {
+			t.Errorf("ParseRule(%q) = %s:%s, want %s:%s", tt.in, r.Session, r.Pattern, tt.session, tt.pattern)
+		}
+	}
+}
+
+func TestRuleMatchesSession(t *testing.T) {
+	tests := []struct {
+		rule, session string
+		want          bool
+	}{
+		{"*:x", "api", true},
+		{"api:x", "api", true},
+		{"api:x", "api2", false},
+		{"api*:x", "api2", true},
+		{"web-?:x", "web-1", true},
+	}
+	for _, tt := range tests {
+		r, err := ParseRule(tt.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.MatchesSession(tt.session); got != tt.want {
+			t.Errorf("%s MatchesSession(%q) = %v, want %v", tt.rule, tt.session, got, tt.want)
+		}
+	}
+}
+
+// captureClient serves pane captures; other Client methods are not used.
+type captureClient struct {
+	byobu.Client
+	captures map[string]byobu.Capture
+}
+
+func (c captureClient) CapturePane(target string, lines int) (byobu.Capture, error) {
+	return c.captures[target], nil
+}
+
+func capture(first int, text string) byobu.Capture {
+	return byobu.Capture{Lines: strings.Split(text, "\n"), First: first}
+}
+
+func TestMatcherCheck(t *testing.T) {
+	panes := []byobu.Pane{
+		{SessionName: "api", ID: "%1"},
+		{SessionName: "web", ID: "%2"},
+	}
+	tests := []struct {
+		name  string
+		steps []byobu.Capture // Successive captures of %1
+		want  []int           // New alerts per step
+	}{
+		{"existing output is not reported", []byobu.Capture{
+			capture(0, "ok\nFAIL"),
+		}, []int{0}},
+		{"new line", []byobu.Capture{
+			capture(0, "ok\n"),
+			capture(0, "ok\nFAIL"),
+		}, []int{0, 1}},
+		{"line in scrollback only once", []byobu.Capture{
+			capture(0, "ok\n"),
+			capture(0, "ok\nFAIL\n"),
+			capture(0, "ok\nFAIL\nmore"),
+			capture(1, "FAIL\nmore\nmore"),
+		}, []int{0, 1, 0, 0}},
+		{"repeated line", []byobu.Capture{
+			capture(0, "FAIL\n"),
+			capture(0, "FAIL\nFAIL"),
+			capture(0, "FAIL\nFAIL\nFAIL"),
+		}, []int{0, 1, 1}},
+		{"full history", []byobu.Capture{
+			capture(100, "a\nb"),
+			capture(100, "b\nFAIL"),
+		}, []int{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, _ := ParseRule("api:FAIL")
+			m := NewMatcher([]Rule{rule})
+			for i, c := range tt.steps {
+				client := captureClient{captures: map[string]byobu.Capture{
+					"%1": c,
+					"%2": capture(0, "FAIL"), // Not covered by the rule
+				}}
+				events := m.Check(client, panes, time.Now())
+				if len(events) != tt.want[i] {
+					t.Errorf("step %d: got %d events %v, want %d", i, len(events), events, tt.want[i])
+				}
+				for _, e := range events {
+					if e.Kind != EventMatch || e.Session != "api" {
+						t.Errorf("step %d: unexpected event %v", i, e)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package watch
+
+import (
+	"byoman/internal/byobu"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kind identifies what happened in a session.
+type Kind int
+
+const (
+	EventBell    Kind = iota // A window rang the bell
+	EventSilence             // Output stopped after a long-running burst
+	EventExit                // The foreground command of a pane exited
+	EventMatch               // Pane output matched an alert rule
+)
+
+// String returns the name used in logs and hook environment.
+func (k Kind) String() string {
+	switch k {
+	case EventBell:
+		return "bell"
+	case EventSilence:
+		return "silence"
+	case EventExit:
+		return "exit"
+	case EventMatch:
+		return "match"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is something in a detached session that needs attention.
+type Event struct {
+	Kind    Kind
+	Session string
+	Window  int
+	Message string
+	Time    time.Time
+}
+
+// String formats the event as a single log line.
+func (e Event) String() string {
+	return fmt.Sprintf("%s  %-8s %s:%d  %s", e.Time.Format("15:04:05"), e.Kind, e.Session, e.Window, e.Message)
+}
+
+// Options tune the silence heuristic.
+type Options struct {
+	Silence time.Duration // Quiet period that counts as "gone silent"
+	MinRun  time.Duration // Minimum burst of output before silence is reported
+}
+
+// DefaultOptions returns the options used when none are configured.
+func DefaultOptions() Options {
+	return Options{Silence: 30 * time.Second, MinRun: time.Minute}
+}
+
+// windowState tracks a window between polls.
+type windowState struct {
+	bell        bool
+	activity    time.Time // Last reported window activity
+	streakStart time.Time // First activity of the current burst
+	silenced    bool      // Silence already reported for this burst
+}
+
+// Tracker turns successive snapshots into events. Only detached sessions
+// produce events; the first snapshot only seeds state.
+type Tracker struct {
+	opts Options
+
+	mu       sync.Mutex
+	seeded   bool
+	windows  map[string]*windowState // Window ID -> state
+	commands map[string]string       // Pane ID -> foreground command
+}
+
+// NewTracker creates a tracker.
+func NewTracker(opts Options) *Tracker {
+	return &Tracker{
+		opts:     opts,
+		windows:  make(map[string]*windowState),
+		commands: make(map[string]string),
+	}
+}
+
+// Update compares a snapshot with the previous one and returns new events.
+// Sessions must have Windows populated.
+func (t *Tracker) Update(sessions []byobu.Session, panes []byobu.Pane, now time.Time) []Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var events []Event
+	detached := make(map[string]bool, len(sessions))
+	windows := make(map[string]*windowState)
+
+	for _, s := range sessions {
+		detached[s.Name] = s.IsDetached()
+		for _, w := range s.Windows {
+			prev, ok := t.windows[w.ID]
+			if !ok {
+				prev = &windowState{activity: w.Activity, streakStart: w.Activity}
+			}
+			state := *prev
+			state.bell = w.BellFlag
+
+			if w.Activity.After(prev.activity) {
+				// Output resumed; a gap longer than the silence period starts a new burst
+				if w.Activity.Sub(prev.activity) > t.opts.Silence {
+					state.streakStart = w.Activity
+				}
+				state.activity = w.Activity
+				state.silenced = false
+			}
+
+			if t.seeded && s.IsDetached() {
+				if w.BellFlag && !prev.bell {
+					events = append(events, Event{EventBell, s.Name, w.Index, fmt.Sprintf("bell in window '%s'", w.Name), now})
+				}
+				quiet := now.Sub(state.activity)
+				burst := state.activity.Sub(state.streakStart)
+				if !state.silenced && quiet >= t.opts.Silence && burst >= t.opts.MinRun {
+					state.silenced = true
+					events = append(events, Event{EventSilence, s.Name, w.Index,
+						fmt.Sprintf("window '%s' silent for %s after %s of output", w.Name, quiet.Round(time.Second), burst.Round(time.Second)), now})
+				}
+			}
+			windows[w.ID] = &state
+		}
+	}
+
+	commands := make(map[string]string, len(panes))
+	for _, p := range panes {
+		commands[p.ID] = p.CurrentCommand
+		prev, ok := t.commands[p.ID]
+		if t.seeded && ok && detached[p.SessionName] && !IsShell(prev) && IsShell(p.CurrentCommand) {
+			events = append(events, Event{EventExit, p.SessionName, p.WindowIndex,
+				fmt.Sprintf("'%s' exited in pane %d.%d", prev, p.WindowIndex, p.Index), now})
+		}
+	}
+
+	t.windows = windows
+	t.commands = commands
+	t.seeded = true
+	return events
+}
+
+// shells are foreground commands that mean "nothing running".
+var shells = map[string]bool{
+	"bash": true, "zsh": true, "fish": true, "sh": true, "dash": true,
+	"ksh": true, "tcsh": true, "csh": true, "nu": true,
+}
+
+// IsShell reports whether command is an interactive shell.
+func IsShell(command string) bool {
+	return shells[strings.TrimPrefix(command, "-")]
+}
+
+// Load fetches sessions (with windows) and panes for a Tracker update.
+func Load(client byobu.Client) ([]byobu.Session, []byobu.Pane, error) {
+	sessions, err := client.ListSessions()
+	if err != nil {
+		return nil, nil, err
+	}
+	windows, err := client.ListWindows()
+	if err != nil {
+		return nil, nil, err
+	}
+	panes, err := client.ListPanes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bySession := make(map[string][]byobu.Window)
+	for _, w := range windows {
+		bySession[w.SessionName] = append(bySession[w.SessionName], w)
+	}
+	for i := range sessions {
+		sessions[i].Windows = bySession[sessions[i].Name]
+	}
+	return sessions, panes, nil
+}
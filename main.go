@@ -18,7 +18,23 @@ func main() {
 		return
 	}
 
-	if err := app.Run(); err != nil {
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "new":
+		err = app.New(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "share":
+		err = app.Share(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "send":
+		err = app.Send(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "watch":
+		err = app.Watch(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "pipe-log":
+		err = app.PipeLog(os.Args[2:])
+	default:
+		err = app.Run()
+	}
+
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}